@@ -1,6 +1,9 @@
 CREATE EXTENSION IF NOT EXISTS CITEXT;
 
-DROP TABLE IF EXISTS client, forum, thread, post, vote, forum_client;
+DROP TABLE IF EXISTS client, forum, thread, post, vote, forum_client, post_vote, post_reaction, post_revision, thread_revision, outbox, outbox_dead_letter, webhook, webhook_delivery, mention, thread_subscription, forum_subscription, notification, post_quarantine, forum_moderator, post_report, moderation_item, moderation_action, ban, conversation, conversation_member, private_message, tag, thread_tag, bookmark;
+
+-- Autovacuum is left on only for the tables whose rows keep changing once written:
+-- conversation members, the outbox, webhooks and their deliveries, notifications and moderation items
 
 -- Client
 
@@ -9,7 +12,10 @@ CREATE UNLOGGED TABLE IF NOT EXISTS client (
   email CITEXT NOT NULL UNIQUE,
   nickname CITEXT NOT NULL UNIQUE,
   fullname TEXT NOT NULL,
-  about TEXT NOT NULL DEFAULT ''
+  about TEXT NOT NULL DEFAULT '',
+  reputation INTEGER NOT NULL DEFAULT 0,
+  posts INTEGER NOT NULL DEFAULT 0,
+  threads INTEGER NOT NULL DEFAULT 0
 ) WITH (autovacuum_enabled = FALSE);
 
 CREATE INDEX IF NOT EXISTS client_email_index
@@ -18,19 +24,77 @@ CREATE INDEX IF NOT EXISTS client_email_index
 CREATE INDEX IF NOT EXISTS client_covering_index
   ON client(nickname) INCLUDE (email, fullname, about, id);
 
+CREATE INDEX IF NOT EXISTS client_nickname_prefix_index
+  ON client(lower(nickname::TEXT) text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS client_fullname_prefix_index
+  ON client(lower(fullname) text_pattern_ops);
+
+-- Conversation
+
+CREATE UNLOGGED TABLE IF NOT EXISTS conversation (
+  id BIGSERIAL PRIMARY KEY,
+  subject TEXT NOT NULL DEFAULT '',
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS conversation_member (
+  conversation_id BIGINT NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  last_message_id BIGINT NOT NULL DEFAULT 0,
+  last_read_id BIGINT NOT NULL DEFAULT 0,
+  unread INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (conversation_id, user_nickname)
+);
+
+CREATE INDEX IF NOT EXISTS conversation_member_user_nickname_index
+  ON conversation_member(user_nickname, conversation_id);
+
+CREATE INDEX IF NOT EXISTS conversation_member_unread_index
+  ON conversation_member(user_nickname, conversation_id) WHERE unread > 0;
+
+CREATE UNLOGGED TABLE IF NOT EXISTS private_message (
+  id BIGSERIAL PRIMARY KEY,
+  conversation_id BIGINT NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  message TEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS private_message_conversation_id_index
+  ON private_message(conversation_id, id);
+
 -- Forum
 
+-- Forums without a parent are the roots of the hierarchy, categories only group subforums and hold no threads
 CREATE UNLOGGED TABLE IF NOT EXISTS forum (
   id SERIAL PRIMARY KEY,
   slug CITEXT NOT NULL,
   title TEXT NOT NULL,
   threads INTEGER NOT NULL DEFAULT 0,
   posts BIGINT NOT NULL DEFAULT 0,
-  user_nickname CITEXT NOT NULL
+  user_nickname CITEXT NOT NULL,
+  parent_slug CITEXT DEFAULT NULL,
+  category BOOLEAN NOT NULL DEFAULT FALSE
 ) WITH (autovacuum_enabled = FALSE);
 
 CREATE INDEX IF NOT EXISTS forum_slug_index
-  ON forum(slug) INCLUDE (title, posts, threads, user_nickname, id);
+  ON forum(slug) INCLUDE (title, posts, threads, user_nickname, id, parent_slug, category);
+
+CREATE INDEX IF NOT EXISTS forum_parent_slug_index
+  ON forum(parent_slug, title, id);
+
+CREATE INDEX IF NOT EXISTS forum_user_nickname_index
+  ON forum(user_nickname);
+
+CREATE INDEX IF NOT EXISTS forum_title_index
+  ON forum(title, id);
+
+CREATE INDEX IF NOT EXISTS forum_threads_index
+  ON forum(threads, id);
+
+CREATE INDEX IF NOT EXISTS forum_posts_index
+  ON forum(posts, id);
 
 -- Thread
 
@@ -42,8 +106,9 @@ CREATE UNLOGGED TABLE IF NOT EXISTS thread (
   forum_id INTEGER NOT NULL,
   forum_slug CITEXT NOT NULL,
   user_nickname CITEXT NOT NULL,
-  created TIMESTAMPTZ,
-  votes INTEGER NOT NULL DEFAULT 0
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  votes INTEGER NOT NULL DEFAULT 0,
+  message_html TEXT
 ) WITH (autovacuum_enabled = FALSE);
 
 CREATE INDEX IF NOT EXISTS thread_slug_index
@@ -53,7 +118,62 @@ CREATE INDEX IF NOT EXISTS thread_func_id_index
   ON thread(text(id));
 
 CREATE INDEX IF NOT EXISTS thread_created_index
-  ON thread(forum_slug, created);
+  ON thread(forum_slug, created, id);
+
+CREATE INDEX IF NOT EXISTS thread_user_nickname_created_index
+  ON thread(user_nickname, created, id);
+
+CREATE INDEX IF NOT EXISTS thread_search_index
+  ON thread USING GIN ((setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', COALESCE(message, '')), 'B')));
+
+-- Tag
+
+CREATE UNLOGGED TABLE IF NOT EXISTS tag (
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL UNIQUE
+) WITH (autovacuum_enabled = FALSE);
+
+-- Thread tag
+
+-- Forum and creation time are copied from the thread, so tag listings are filtered and ordered by index
+CREATE UNLOGGED TABLE IF NOT EXISTS thread_tag (
+  tag_id INTEGER NOT NULL,
+  thread_id INTEGER NOT NULL,
+  forum_slug CITEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (tag_id, thread_id)
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS thread_tag_created_index
+  ON thread_tag(tag_id, created, thread_id);
+
+CREATE INDEX IF NOT EXISTS thread_tag_forum_created_index
+  ON thread_tag(forum_slug, tag_id, created, thread_id);
+
+CREATE INDEX IF NOT EXISTS thread_tag_thread_id_index
+  ON thread_tag(thread_id);
+
+-- Tags of a thread in name order, the tag tables are the only place they are kept
+CREATE OR REPLACE FUNCTION thread_tags(INTEGER) RETURNS TEXT[] AS $$
+  SELECT COALESCE(array_agg(g.name ORDER BY g.name), '{}')
+  FROM thread_tag AS tt
+  JOIN tag AS g ON (g.id = tt.tag_id)
+  WHERE tt.thread_id = $1
+$$ LANGUAGE SQL STABLE;
+
+-- Thread revision
+
+CREATE UNLOGGED TABLE IF NOT EXISTS thread_revision (
+  id SERIAL PRIMARY KEY,
+  thread_id INTEGER NOT NULL,
+  title TEXT NOT NULL,
+  message TEXT NULL,
+  editor CITEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS thread_revision_thread_id_index
+  ON thread_revision(thread_id, id);
 
 -- Post
 
@@ -67,7 +187,11 @@ CREATE UNLOGGED TABLE IF NOT EXISTS post (
   forum_slug CITEXT NOT NULL,
   parent INT DEFAULT 0,
   parents INT [] NOT NULL,
-  root INT NOT NULL
+  root INT NOT NULL,
+  votes INTEGER NOT NULL DEFAULT 0,
+  reactions JSONB NOT NULL DEFAULT '{}',
+  mentions TEXT[] NOT NULL DEFAULT '{}',
+  message_html TEXT
 ) WITH (autovacuum_enabled = FALSE);
 
 CREATE INDEX IF NOT EXISTS post_id_thread_index
@@ -82,9 +206,59 @@ CREATE INDEX IF NOT EXISTS post_parent_tree_index
 CREATE INDEX IF NOT EXISTS post_thread_id_index
   ON post(thread_id, id);
 
+CREATE INDEX IF NOT EXISTS post_user_nickname_id_index
+  ON post(user_nickname, id);
+
 CREATE INDEX IF NOT EXISTS post_root_parents_func_index
   ON post(root, array_append(parents, id));
 
+CREATE INDEX IF NOT EXISTS post_parent_votes_index
+  ON post(thread_id, votes DESC, id) WHERE parent = 0;
+
+CREATE INDEX IF NOT EXISTS post_search_index
+  ON post USING GIN (to_tsvector('simple', message));
+
+-- Mention
+
+CREATE UNLOGGED TABLE IF NOT EXISTS mention (
+  post_id INTEGER NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  PRIMARY KEY (post_id, user_nickname)
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS mention_user_nickname_index
+  ON mention(user_nickname, post_id);
+
+-- Post quarantine
+
+-- Posts flagged by a content filter wait here instead of appearing in their thread
+CREATE UNLOGGED TABLE IF NOT EXISTS post_quarantine (
+  id SERIAL PRIMARY KEY,
+  thread_id INTEGER NOT NULL,
+  forum_slug CITEXT NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  message TEXT NOT NULL,
+  parent INTEGER NOT NULL DEFAULT 0,
+  reason TEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS post_quarantine_forum_slug_index
+  ON post_quarantine(forum_slug, id);
+
+-- Post revision
+
+CREATE UNLOGGED TABLE IF NOT EXISTS post_revision (
+  id SERIAL PRIMARY KEY,
+  post_id INTEGER NOT NULL,
+  message TEXT NOT NULL,
+  editor CITEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS post_revision_post_id_index
+  ON post_revision(post_id, id);
+
 -- Vote
 
 CREATE UNLOGGED TABLE IF NOT EXISTS vote (
@@ -97,6 +271,32 @@ CREATE UNLOGGED TABLE IF NOT EXISTS vote (
 CREATE INDEX IF NOT EXISTS vote_user_nickname_thread_id_index
   ON vote(user_nickname, thread_id);
 
+CREATE INDEX IF NOT EXISTS vote_thread_id_user_nickname_index
+  ON vote(thread_id, user_nickname);
+
+-- Post vote
+
+CREATE UNLOGGED TABLE IF NOT EXISTS post_vote (
+  id SERIAL PRIMARY KEY,
+  voice BOOLEAN,
+  user_nickname CITEXT NOT NULL,
+  post_id INTEGER NOT NULL
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE UNIQUE INDEX IF NOT EXISTS post_vote_post_id_user_nickname_index
+  ON post_vote(post_id, user_nickname);
+
+-- Post reaction
+
+CREATE UNLOGGED TABLE IF NOT EXISTS post_reaction (
+  post_id INTEGER NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  reaction TEXT NOT NULL
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE UNIQUE INDEX IF NOT EXISTS post_reaction_index
+  ON post_reaction(post_id, user_nickname, reaction);
+
 -- Forum client
 
 CREATE UNLOGGED TABLE IF NOT EXISTS forum_client (
@@ -104,11 +304,223 @@ CREATE UNLOGGED TABLE IF NOT EXISTS forum_client (
   email CITEXT NOT NULL,
   nickname CITEXT NOT NULL,
   fullname TEXT NOT NULL,
-  about TEXT NOT NULL DEFAULT ''
+  about TEXT NOT NULL DEFAULT '',
+  reputation INTEGER NOT NULL DEFAULT 0
 ) WITH (autovacuum_enabled = FALSE);
 
 CREATE UNIQUE INDEX IF NOT EXISTS forum_client_index
   ON forum_client (forum_slug, nickname);
 
 CREATE INDEX IF NOT EXISTS forum_client_covering_index
-  ON forum_client (forum_slug, nickname, email, fullname, about);
\ No newline at end of file
+  ON forum_client (forum_slug, nickname, email, fullname, about);
+
+CREATE INDEX IF NOT EXISTS forum_client_reputation_index
+  ON forum_client (forum_slug, reputation DESC, nickname);
+
+-- Outbox
+
+-- Rows are deleted once every subscriber handled them, handled lists the subscribers done so far.
+-- A dispatcher claims rows until claimed_until, failed rows are claimable again after a backoff.
+CREATE UNLOGGED TABLE IF NOT EXISTS outbox (
+  id BIGSERIAL PRIMARY KEY,
+  type TEXT NOT NULL,
+  thread_id INTEGER NOT NULL DEFAULT 0,
+  forum_slug CITEXT NOT NULL DEFAULT '',
+  payload JSONB NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  handled TEXT[] NOT NULL DEFAULT '{}',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  claimed_until TIMESTAMPTZ
+);
+
+-- Events a subscriber kept failing on, they no longer hold back the outbox
+CREATE UNLOGGED TABLE IF NOT EXISTS outbox_dead_letter (
+  id BIGSERIAL PRIMARY KEY,
+  event_id BIGINT NOT NULL,
+  subscriber TEXT NOT NULL,
+  type TEXT NOT NULL,
+  thread_id INTEGER NOT NULL DEFAULT 0,
+  forum_slug CITEXT NOT NULL DEFAULT '',
+  payload JSONB NOT NULL,
+  error TEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+-- Webhook
+
+CREATE UNLOGGED TABLE IF NOT EXISTS webhook (
+  id SERIAL PRIMARY KEY,
+  forum_slug CITEXT NOT NULL,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  events TEXT[] NOT NULL DEFAULT '{}',
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  failures INTEGER NOT NULL DEFAULT 0,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_forum_slug_index
+  ON webhook(forum_slug, id);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS webhook_delivery (
+  id BIGSERIAL PRIMARY KEY,
+  webhook_id INTEGER NOT NULL,
+  event_id BIGINT NOT NULL,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  state TEXT NOT NULL DEFAULT 'pending',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  status INTEGER,
+  error TEXT,
+  next_attempt TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_delivery_event_index
+  ON webhook_delivery(webhook_id, event_id);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_id_index
+  ON webhook_delivery(webhook_id, id);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_index
+  ON webhook_delivery(next_attempt) WHERE state = 'pending';
+
+-- Subscription
+
+CREATE UNLOGGED TABLE IF NOT EXISTS thread_subscription (
+  user_nickname CITEXT NOT NULL,
+  thread_id INTEGER NOT NULL,
+  PRIMARY KEY (user_nickname, thread_id)
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS thread_subscription_thread_id_index
+  ON thread_subscription(thread_id, user_nickname);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_subscription (
+  user_nickname CITEXT NOT NULL,
+  forum_slug CITEXT NOT NULL,
+  PRIMARY KEY (user_nickname, forum_slug)
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS forum_subscription_forum_slug_index
+  ON forum_subscription(forum_slug, user_nickname);
+
+-- Notification
+
+CREATE UNLOGGED TABLE IF NOT EXISTS notification (
+  id BIGSERIAL PRIMARY KEY,
+  user_nickname CITEXT NOT NULL,
+  event_id BIGINT NOT NULL,
+  kind TEXT NOT NULL,
+  author CITEXT NOT NULL,
+  forum_slug CITEXT NOT NULL,
+  thread_id INTEGER NOT NULL,
+  post_id INTEGER,
+  read BOOLEAN NOT NULL DEFAULT FALSE,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS notification_event_index
+  ON notification(user_nickname, event_id);
+
+CREATE INDEX IF NOT EXISTS notification_user_nickname_index
+  ON notification(user_nickname, id);
+
+CREATE INDEX IF NOT EXISTS notification_unread_index
+  ON notification(user_nickname, id) WHERE NOT read;
+
+-- Moderation
+
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_moderator (
+  forum_slug CITEXT NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  added_by CITEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (forum_slug, user_nickname)
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS post_report (
+  post_id INTEGER NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  reason TEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (post_id, user_nickname)
+) WITH (autovacuum_enabled = FALSE);
+
+-- target_id is a post id for reports and a post_quarantine id for quarantined posts
+CREATE UNLOGGED TABLE IF NOT EXISTS moderation_item (
+  id BIGSERIAL PRIMARY KEY,
+  forum_slug CITEXT NOT NULL,
+  kind TEXT NOT NULL,
+  target_id INTEGER NOT NULL,
+  thread_id INTEGER NOT NULL,
+  user_nickname CITEXT NOT NULL,
+  message TEXT NOT NULL,
+  reasons TEXT[] NOT NULL,
+  reports INTEGER NOT NULL DEFAULT 0,
+  resolved BOOLEAN NOT NULL DEFAULT FALSE,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS moderation_item_open_index
+  ON moderation_item(kind, target_id) WHERE NOT resolved;
+
+CREATE INDEX IF NOT EXISTS moderation_item_forum_slug_index
+  ON moderation_item(forum_slug, id) WHERE NOT resolved;
+
+CREATE UNLOGGED TABLE IF NOT EXISTS moderation_action (
+  id BIGSERIAL PRIMARY KEY,
+  item_id BIGINT NOT NULL,
+  forum_slug CITEXT NOT NULL,
+  moderator CITEXT NOT NULL,
+  action TEXT NOT NULL,
+  note TEXT NOT NULL DEFAULT '',
+  user_nickname CITEXT NOT NULL,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS moderation_action_forum_slug_index
+  ON moderation_action(forum_slug, id);
+
+-- Ban
+
+-- A ban without forum_slug applies to every forum, one without expires never runs out
+CREATE UNLOGGED TABLE IF NOT EXISTS ban (
+  id BIGSERIAL PRIMARY KEY,
+  user_nickname CITEXT NOT NULL,
+  forum_slug CITEXT,
+  reason TEXT NOT NULL DEFAULT '',
+  moderator CITEXT NOT NULL,
+  expires TIMESTAMPTZ,
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE INDEX IF NOT EXISTS ban_user_nickname_index
+  ON ban(user_nickname, forum_slug);
+
+-- Bookmark
+
+-- A bookmark without post_id points at the thread itself
+CREATE UNLOGGED TABLE IF NOT EXISTS bookmark (
+  id BIGSERIAL PRIMARY KEY,
+  user_nickname CITEXT NOT NULL,
+  post_id INTEGER,
+  thread_id INTEGER NOT NULL,
+  forum_slug CITEXT NOT NULL,
+  folder TEXT,
+  note TEXT NOT NULL DEFAULT '',
+  created TIMESTAMPTZ NOT NULL DEFAULT NOW()
+) WITH (autovacuum_enabled = FALSE);
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookmark_user_nickname_target_index
+  ON bookmark(user_nickname, thread_id, COALESCE(post_id, 0));
+
+CREATE INDEX IF NOT EXISTS bookmark_user_nickname_index
+  ON bookmark(user_nickname, id);
+
+CREATE INDEX IF NOT EXISTS bookmark_user_nickname_folder_index
+  ON bookmark(user_nickname, folder, id);
+
+CREATE INDEX IF NOT EXISTS bookmark_post_id_index
+  ON bookmark(post_id) WHERE post_id IS NOT NULL;
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/infrastructure/repository/postgresql"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+	"github.com/jackc/pgx"
+)
+
+// Recomputes user reputation, post and thread counters from the stored votes
+func main() {
+	pgxConf := postgresql.ConnPoolConfig(1)
+
+	conn, err := pgx.NewConnPool(pgxConf)
+	if err != nil {
+		log.Fatal("database connection refused")
+	}
+	defer conn.Close()
+
+	// Create prepared statements
+	postgresql.PrepareStatements(conn)
+
+	serviceInteractor := usecase.NewServiceInteractor(postgresql.NewServiceRepo(conn))
+	if err := serviceInteractor.RecomputeReputation(); err != nil {
+		log.Fatal("recompute reputation failed:", err)
+	}
+
+	log.Println("reputation recomputed")
+}
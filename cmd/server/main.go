@@ -2,24 +2,34 @@ package main
 
 import (
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/guard"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/abuse"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/infrastructure/bus"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/infrastructure/filter"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/infrastructure/ratelimit"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/infrastructure/repository/postgresql"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/infrastructure/webhook"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
 	"github.com/jackc/pgx"
 	"github.com/valyala/fasthttp"
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	eventBuffer      = 64
+	dispatchInterval = 100 * time.Millisecond
+	dispatchBatch    = 500
+	webhookInterval  = time.Second
+	webhookTimeout   = 10 * time.Second
 )
 
 func main() {
-	pgxConf := pgx.ConnPoolConfig{
-		ConnConfig: pgx.ConnConfig{
-			Host:     "localhost",
-			Port:     5432,
-			Database: "docker",
-			User:     "docker",
-			Password: "docker",
-		},
-		MaxConnections: 50,
-	}
+	pgxConf := postgresql.ConnPoolConfig(50)
 
 	conn, err := pgx.NewConnPool(pgxConf)
 	if err != nil {
@@ -38,15 +48,196 @@ func main() {
 	// Create prepared statements
 	postgresql.PrepareStatements(conn)
 
+	// Events published by interactors, each stream subscriber may lag behind by eventBuffer events
+	eventBus := bus.NewBus(eventBuffer)
+
 	// Create interactors
-	userInteractor := usecase.NewUserInteractor(postgresql.NewUserRepo(conn))
-	forumInteractor := usecase.NewForumInteractor(postgresql.NewForumRepo(conn))
-	threadInteractor := usecase.NewThreadInteractor(postgresql.NewThreadRepo(conn))
-	postInteractor := usecase.NewPostInteractor(postgresql.NewPostRepo(conn))
-	voteInteractor := usecase.NewVoteInteractor(postgresql.NewVoteRepo(conn))
+	validator := usecase.NewValidator(limitsFromEnv())
+	// Moderators and forum owners prove their nickname with tokens signed by FORUM_AUTH_SECRET, see cmd/token
+	identities := usecase.NewIdentities(os.Getenv("FORUM_AUTH_SECRET"))
+	banInteractor := usecase.NewBanInteractor(postgresql.NewBanRepo(conn), postgresql.NewModerationRepo(conn), validator, identities, globalModerators())
+	userInteractor := usecase.NewUserInteractor(postgresql.NewUserRepo(conn), validator, banInteractor)
+	forumInteractor := usecase.NewForumInteractor(postgresql.NewForumRepo(conn), validator)
+	threadInteractor := usecase.NewThreadInteractor(postgresql.NewThreadRepo(conn), validator, banInteractor, identities)
+	// Flood and spam protection is off unless enabled through the environment
+	var authorLimiter repository.RateLimiter
+	if rate := authorRateFromEnv(); rate.Enabled() {
+		authorLimiter = ratelimit.NewLimiter(rate)
+	}
+	protection := usecase.NewProtection(authorLimiter, duplicateWindowFromEnv(), contentFiltersFromEnv()...)
+	postInteractor := usecase.NewPostInteractor(postgresql.NewPostRepo(conn), validator, protection, banInteractor, identities)
+	voteInteractor := usecase.NewVoteInteractor(postgresql.NewVoteRepo(conn), validator, banInteractor)
+	searchInteractor := usecase.NewSearchInteractor(postgresql.NewSearchRepo(conn))
+	streamInteractor := usecase.NewStreamInteractor(eventBus, postgresql.NewThreadRepo(conn), postgresql.NewForumRepo(conn))
+	webhookInteractor := usecase.NewWebhookInteractor(postgresql.NewWebhookRepo(conn), webhook.NewSender(&fasthttp.Client{Dial: webhook.PublicDial(webhookTimeout)}, webhookTimeout), postgresql.NewModerationRepo(conn), validator, identities)
+	notificationInteractor := usecase.NewNotificationInteractor(postgresql.NewNotificationRepo(conn))
+	moderationInteractor := usecase.NewModerationInteractor(postgresql.NewModerationRepo(conn), postgresql.NewPostRepo(conn), validator, banInteractor, identities)
+	conversationInteractor := usecase.NewConversationInteractor(postgresql.NewConversationRepo(conn), validator)
+	bookmarkInteractor := usecase.NewBookmarkInteractor(postgresql.NewBookmarkRepo(conn), validator)
 	serviceInteractor := usecase.NewServiceInteractor(postgresql.NewServiceRepo(conn))
 
-	api := http.NewRestApi(userInteractor, forumInteractor, threadInteractor, postInteractor, voteInteractor, serviceInteractor)
+	// Deliver events recorded in the outbox
+	dispatcher := usecase.NewDispatcher(postgresql.NewOutboxRepo(conn), dispatchInterval, dispatchBatch)
+	dispatcher.Subscribe("stream", eventBus)
+	dispatcher.Subscribe("webhook", webhookInteractor)
+	dispatcher.Subscribe("notification", notificationInteractor)
+	go dispatcher.Run()
+
+	// Send queued webhook deliveries, failed ones are retried with backoff
+	go webhookInteractor.Run(webhookInterval)
+
+	limiters := make(map[string]guard.Limiter)
+	for route, rate := range writeRatesFromEnv() {
+		if rate.Enabled() {
+			limiters[route] = ratelimit.NewLimiter(rate)
+		}
+	}
+
+	api := http.NewRestApi(userInteractor, forumInteractor, threadInteractor, postInteractor, voteInteractor, searchInteractor, streamInteractor, webhookInteractor, notificationInteractor, moderationInteractor, banInteractor, conversationInteractor, bookmarkInteractor, serviceInteractor, identities, limiters)
 
 	log.Fatal(fasthttp.ListenAndServe(":5000", api.Router.Handler))
 }
+
+// authorRateFromEnv reads the posts per author FORUM_AUTHOR_RATE, for example 100/1s:2000,
+// unset or 0 leaves authors unlimited
+func authorRateFromEnv() ratelimit.Rate {
+	raw := os.Getenv("FORUM_AUTHOR_RATE")
+	if raw == "" {
+		return ratelimit.Rate{}
+	}
+
+	rate, err := ratelimit.ParseRate(raw)
+	if err != nil {
+		log.Fatal("invalid FORUM_AUTHOR_RATE:", raw)
+	}
+
+	return rate
+}
+
+// writeRatesFromEnv reads the per client and per user rates of write routes from the comma separated
+// FORUM_WRITE_RATES, for example "POST /api/post/:id/report=1/1s:20,POST /api/thread/:slug_or_id/create=200/1s:2000",
+// routes left out are unlimited
+func writeRatesFromEnv() map[string]ratelimit.Rate {
+	rates := make(map[string]ratelimit.Rate)
+	for _, entry := range strings.Split(os.Getenv("FORUM_WRITE_RATES"), ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		idx := strings.LastIndexByte(entry, '=')
+		if idx == -1 {
+			log.Fatal("invalid FORUM_WRITE_RATES entry:", entry)
+		}
+
+		rate, err := ratelimit.ParseRate(entry[idx+1:])
+		if err != nil {
+			log.Fatal("invalid FORUM_WRITE_RATES entry:", entry)
+		}
+		rates[strings.TrimSpace(entry[:idx])] = rate
+	}
+
+	return rates
+}
+
+// duplicateWindowFromEnv reads FORUM_DUPLICATE_WINDOW, for example 30s, the time an identical post
+// of the same author is rejected for, unset or 0 allows duplicates
+func duplicateWindowFromEnv() time.Duration {
+	raw := os.Getenv("FORUM_DUPLICATE_WINDOW")
+	if raw == "" {
+		return 0
+	}
+
+	window, err := time.ParseDuration(raw)
+	if err != nil || window < 0 {
+		log.Fatal("invalid FORUM_DUPLICATE_WINDOW:", raw)
+	}
+
+	return window
+}
+
+// contentFiltersFromEnv rejects posts with a word of the comma separated FORUM_BLOCKED_WORDS
+// and quarantines posts with more links than FORUM_MAX_LINKS, both are off when unset
+func contentFiltersFromEnv() []repository.ContentFilter {
+	filters := make([]repository.ContentFilter, 0, 2)
+
+	words := make([]string, 0)
+	for _, word := range strings.Split(os.Getenv("FORUM_BLOCKED_WORDS"), ",") {
+		if word = strings.TrimSpace(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	if len(words) != 0 {
+		filters = append(filters, filter.NewWordList(words, abuse.ActionReject))
+	}
+
+	if raw := os.Getenv("FORUM_MAX_LINKS"); raw != "" {
+		max, err := strconv.Atoi(raw)
+		if err != nil || max < 0 {
+			log.Fatal("invalid FORUM_MAX_LINKS:", raw)
+		}
+		filters = append(filters, filter.NewLinkLimit(max, abuse.ActionQuarantine))
+	}
+
+	return filters
+}
+
+// limitsFromEnv overrides the default validation limits with the comma separated FORUM_LIMITS,
+// for example "MessageLength=10000,Tags=5" with the names of the usecase.Limits fields
+func limitsFromEnv() usecase.Limits {
+	limits := usecase.DefaultLimits()
+	fields := map[string]*int{
+		"EmailLength":    &limits.EmailLength,
+		"NicknameLength": &limits.NicknameLength,
+		"FullnameLength": &limits.FullnameLength,
+		"AboutLength":    &limits.AboutLength,
+		"SlugLength":     &limits.SlugLength,
+		"TitleLength":    &limits.TitleLength,
+		"TagLength":      &limits.TagLength,
+		"FolderLength":   &limits.FolderLength,
+		"MessageLength":  &limits.MessageLength,
+		"ReasonLength":   &limits.ReasonLength,
+		"NoteLength":     &limits.NoteLength,
+		"ReactionLength": &limits.ReactionLength,
+		"URLLength":      &limits.URLLength,
+		"SecretLength":   &limits.SecretLength,
+		"PostsBatch":     &limits.PostsBatch,
+		"Recipients":     &limits.Recipients,
+		"Tags":           &limits.Tags,
+	}
+
+	for _, entry := range strings.Split(os.Getenv("FORUM_LIMITS"), ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+
+		idx := strings.IndexByte(entry, '=')
+		if idx == -1 {
+			log.Fatal("invalid FORUM_LIMITS entry:", entry)
+		}
+
+		field, ok := fields[strings.TrimSpace(entry[:idx])]
+		if !ok {
+			log.Fatal("unknown FORUM_LIMITS entry:", entry)
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(entry[idx+1:]))
+		if err != nil || value <= 0 {
+			log.Fatal("invalid FORUM_LIMITS entry:", entry)
+		}
+		*field = value
+	}
+
+	return limits
+}
+
+// globalModerators reads the comma separated nicknames allowed to ban users from every forum
+func globalModerators() []string {
+	nicknames := make([]string, 0)
+	for _, nickname := range strings.Split(os.Getenv("FORUM_GLOBAL_MODERATORS"), ",") {
+		if nickname = strings.TrimSpace(nickname); nickname != "" {
+			nicknames = append(nicknames, nickname)
+		}
+	}
+
+	return nicknames
+}
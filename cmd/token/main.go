@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+)
+
+// Prints an identity token for every nickname given, signed with FORUM_AUTH_SECRET
+func main() {
+	secret := os.Getenv("FORUM_AUTH_SECRET")
+	if secret == "" {
+		log.Fatal("FORUM_AUTH_SECRET is not set")
+	}
+
+	identities := usecase.NewIdentities(secret)
+	for _, nickname := range os.Args[1:] {
+		fmt.Println(identities.Issue(nickname))
+	}
+}
@@ -1,60 +1,182 @@
 package http
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/guard"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/ban"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/bookmark"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/conversation"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/forum"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/moderation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/notification"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/search"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/service"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/stream"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/thread"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/user"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/vote"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/handlers/webhook"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/identity"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
 	"github.com/buaazp/fasthttprouter"
+	"github.com/valyala/fasthttp"
 )
 
 type Api struct {
 	Router *fasthttprouter.Router
 }
 
+// limitedRouter puts write routes behind the per client and per user limiter configured for "METHOD /path"
+type limitedRouter struct {
+	*fasthttprouter.Router
+	limiters map[string]guard.Limiter
+	identify guard.Identify
+}
+
+func (r *limitedRouter) POST(path string, handler fasthttp.RequestHandler) {
+	r.Router.POST(path, r.limit("POST", path, handler))
+}
+
+func (r *limitedRouter) DELETE(path string, handler fasthttp.RequestHandler) {
+	r.Router.DELETE(path, r.limit("DELETE", path, handler))
+}
+
+func (r *limitedRouter) limit(method, path string, handler fasthttp.RequestHandler) fasthttp.RequestHandler {
+	if limiter, ok := r.limiters[method+" "+path]; ok {
+		return guard.Limit(limiter, r.identify, handler)
+	}
+	return handler
+}
+
 func NewRestApi(
 	userInteractor *usecase.UserInteractor,
 	forumInteractor *usecase.ForumInteractor,
 	threadInteractor *usecase.ThreadInteractor,
 	postInteractor *usecase.PostInteractor,
 	voteInteractor *usecase.VoteInteractor,
+	searchInteractor *usecase.SearchInteractor,
+	streamInteractor *usecase.StreamInteractor,
+	webhookInteractor *usecase.WebhookInteractor,
+	notificationInteractor *usecase.NotificationInteractor,
+	moderationInteractor *usecase.ModerationInteractor,
+	banInteractor *usecase.BanInteractor,
+	conversationInteractor *usecase.ConversationInteractor,
+	bookmarkInteractor *usecase.BookmarkInteractor,
 	serviceInteractor *usecase.ServiceInteractor,
+	identities *usecase.Identities,
+	limiters map[string]guard.Limiter,
 ) *Api {
-	router := fasthttprouter.New()
+	router := &limitedRouter{
+		Router:   fasthttprouter.New(),
+		limiters: limiters,
+		identify: func(ctx *fasthttp.RequestCtx) string {
+			nickname, err := identities.Verify(identity.Token(ctx))
+			if err != nil {
+				return ""
+			}
+			return nickname
+		},
+	}
 
 	//User routes
 	router.POST("/api/user/:nickname/create", user.CreateUser(userInteractor))
 	router.GET("/api/user/:nickname/profile", user.GetUserByNickname(userInteractor))
 	router.POST("/api/user/:nickname/profile", user.UpdateUser(userInteractor))
+	router.GET("/api/users", user.GetUsers(userInteractor))
 
 	//Forum routes
 	router.POST("/api/forum/:slug", forum.CreateForum(forumInteractor))
 	router.GET("/api/forum/:slug/details", forum.GetForum(forumInteractor))
 	router.GET("/api/forum/:slug/users", forum.GetForumUsers(forumInteractor))
+	router.GET("/api/forum/:slug/leaders", forum.GetForumLeaders(forumInteractor))
+	router.GET("/api/forums", forum.ListForums(forumInteractor))
+	router.GET("/api/forums/tree", forum.GetTree(forumInteractor))
+	router.GET("/api/forum/:slug/tree", forum.GetForumTree(forumInteractor))
 
 	//Thread routes
 	router.GET("/api/thread/:slug_or_id/details", thread.GetThread(threadInteractor))
 	router.GET("/api/forum/:slug/threads", thread.GetThreads(threadInteractor))
 	router.POST("/api/forum/:slug/create", thread.CreateThread(threadInteractor))
 	router.POST("/api/thread/:slug_or_id/details", thread.UpdateThread(threadInteractor))
+	router.GET("/api/thread/:slug_or_id/history", thread.GetThreadHistory(threadInteractor))
+	router.GET("/api/user/:nickname/threads", thread.GetUserThreads(threadInteractor))
+	router.GET("/api/forum/:slug/tags", thread.GetForumTags(threadInteractor))
+	router.GET("/api/tags/:tag/threads", thread.GetTagThreads(threadInteractor))
 
 	//Post routes
 	router.GET("/api/post/:id/details", post.GetPost(postInteractor))
 	router.POST("/api/post/:id/details", post.UpdatePost(postInteractor))
 	router.POST("/api/thread/:slug_or_id/create", post.CreatePosts(postInteractor))
 	router.GET("/api/thread/:slug_or_id/posts", post.GetPosts(postInteractor))
+	router.GET("/api/post/:id/history", post.GetPostHistory(postInteractor))
+	router.GET("/api/post/:id/history/diff", post.GetPostDiff(postInteractor))
+	router.GET("/api/user/:nickname/posts", post.GetUserPosts(postInteractor))
+	router.GET("/api/user/:nickname/mentions", post.GetUserMentions(postInteractor))
 
 	//Vote routes
 	router.POST("/api/thread/:slug_or_id/vote", vote.CreateVote(voteInteractor))
+	router.DELETE("/api/thread/:slug_or_id/vote", vote.DeleteVote(voteInteractor))
+	router.GET("/api/thread/:slug_or_id/votes", vote.GetThreadVotes(voteInteractor))
+	router.GET("/api/user/:nickname/votes", vote.GetUserVotes(voteInteractor))
+	router.POST("/api/post/:id/vote", vote.CreatePostVote(voteInteractor))
+	router.DELETE("/api/post/:id/vote", vote.DeletePostVote(voteInteractor))
+	router.POST("/api/post/:id/reactions", vote.CreateReaction(voteInteractor))
+	router.DELETE("/api/post/:id/reactions", vote.DeleteReaction(voteInteractor))
+
+	//Search routes
+	router.GET("/api/search", search.Search(searchInteractor))
+
+	//Stream routes
+	router.GET("/api/thread/:slug_or_id/stream", stream.ThreadStream(streamInteractor))
+	router.GET("/api/forum/:slug/stream", stream.ForumStream(streamInteractor))
+
+	//Webhook routes
+	router.POST("/api/forum/:slug/webhooks", webhook.CreateWebhook(webhookInteractor))
+	router.GET("/api/forum/:slug/webhooks", webhook.GetWebhooks(webhookInteractor))
+	router.GET("/api/forum/:slug/webhooks/:id/details", webhook.GetWebhook(webhookInteractor))
+	router.POST("/api/forum/:slug/webhooks/:id/details", webhook.UpdateWebhook(webhookInteractor))
+	router.DELETE("/api/forum/:slug/webhooks/:id", webhook.DeleteWebhook(webhookInteractor))
+	router.GET("/api/forum/:slug/webhooks/:id/deliveries", webhook.GetDeliveries(webhookInteractor))
+
+	//Notification routes
+	router.POST("/api/thread/:slug_or_id/subscription", notification.SubscribeThread(notificationInteractor))
+	router.DELETE("/api/thread/:slug_or_id/subscription", notification.UnsubscribeThread(notificationInteractor))
+	router.POST("/api/forum/:slug/subscription", notification.SubscribeForum(notificationInteractor))
+	router.DELETE("/api/forum/:slug/subscription", notification.UnsubscribeForum(notificationInteractor))
+	router.GET("/api/user/:nickname/notifications", notification.GetNotifications(notificationInteractor))
+	router.POST("/api/user/:nickname/notifications/read", notification.MarkRead(notificationInteractor))
+
+	//Moderation routes
+	router.POST("/api/post/:id/report", moderation.ReportPost(moderationInteractor))
+	router.GET("/api/forum/:slug/moderators", moderation.GetModerators(moderationInteractor))
+	router.POST("/api/forum/:slug/moderators", moderation.AddModerator(moderationInteractor))
+	router.DELETE("/api/forum/:slug/moderators/:nickname", moderation.RemoveModerator(moderationInteractor))
+	router.GET("/api/forum/:slug/moderation", moderation.GetQueue(moderationInteractor))
+	router.POST("/api/forum/:slug/moderation/:id", moderation.Resolve(moderationInteractor))
+	router.GET("/api/forum/:slug/moderation/actions", moderation.GetActions(moderationInteractor))
+
+	//Ban routes
+	router.GET("/api/user/:nickname/bans", ban.GetBans(banInteractor))
+	router.POST("/api/user/:nickname/bans", ban.CreateBan(banInteractor))
+	router.DELETE("/api/user/:nickname/bans/:id", ban.LiftBan(banInteractor))
+
+	//Conversation routes
+	router.GET("/api/user/:nickname/messages", conversation.GetConversations(conversationInteractor))
+	router.POST("/api/user/:nickname/messages", conversation.Send(conversationInteractor))
+	router.GET("/api/user/:nickname/messages/:id", conversation.GetMessages(conversationInteractor))
+	router.POST("/api/user/:nickname/messages/:id/read", conversation.MarkRead(conversationInteractor))
+
+	//Bookmark routes
+	router.GET("/api/user/:nickname/bookmarks", bookmark.GetBookmarks(bookmarkInteractor))
+	router.POST("/api/user/:nickname/bookmarks", bookmark.CreateBookmark(bookmarkInteractor))
+	router.GET("/api/user/:nickname/bookmarks/folders", bookmark.GetFolders(bookmarkInteractor))
+	router.DELETE("/api/user/:nickname/bookmarks/:id", bookmark.DeleteBookmark(bookmarkInteractor))
 
 	//Service routes
 	router.GET("/api/service/status", service.GetStatus(serviceInteractor))
 	router.POST("/api/service/clear", service.Clear(serviceInteractor))
 
 	return &Api{
-		Router: router,
+		Router: router.Router,
 	}
 }
@@ -0,0 +1,8 @@
+package format
+
+import "github.com/valyala/fasthttp"
+
+// HTML reports whether the client asked for rendered messages with ?format=html
+func HTML(ctx *fasthttp.RequestCtx) bool {
+	return string(ctx.QueryArgs().Peek("format")) == "html"
+}
@@ -0,0 +1,89 @@
+package guard
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/abuse"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+// Limiter takes cost tokens from the bucket of key or tells how long to wait for them
+type Limiter interface {
+	Allow(key string, cost int) (bool, time.Duration)
+}
+
+// Identify returns the verified nickname behind a request, empty for anonymous requests
+type Identify func(ctx *fasthttp.RequestCtx) string
+
+// Limit answers 429 once the client address, or the user the request is signed for,
+// runs out of tokens for the wrapped route
+func Limit(limiter Limiter, identify Identify, handler fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		keys := []string{"ip:" + ctx.RemoteIP().String()}
+		if nickname := identify(ctx); nickname != "" {
+			keys = append(keys, "user:"+strings.ToLower(nickname))
+		}
+
+		for _, key := range keys {
+			if ok, wait := limiter.Allow(key, 1); !ok {
+				ctx.SetContentType("application/json")
+				Write(ctx, &abuse.Error{
+					Description: "Too many requests, try again later",
+					Reason:      abuse.ReasonRateLimited,
+					RetryAfter:  wait,
+				})
+				return
+			}
+		}
+
+		handler(ctx)
+	}
+}
+
+// Write answers a refused write when err is an abuse or ban error and reports whether it did:
+// 429 with Retry-After for rate limits, 409 for duplicates and 403 for filtered content and bans
+func Write(ctx *fasthttp.RequestCtx, err error) bool {
+	if banned, ok := err.(*ban.Error); ok {
+		if _, err := easyjson.MarshalToWriter(banned, ctx.Response.BodyWriter()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return true
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		return true
+	}
+
+	refused, ok := err.(*abuse.Error)
+	if !ok {
+		return false
+	}
+
+	if _, err := easyjson.MarshalToWriter(refused, ctx.Response.BodyWriter()); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		return true
+	}
+
+	switch refused.Reason {
+	case abuse.ReasonRateLimited:
+		{
+			seconds := int(math.Ceil(refused.RetryAfter.Seconds()))
+			ctx.Response.Header.Set("Retry-After", strconv.Itoa(seconds))
+			ctx.SetStatusCode(fasthttp.StatusTooManyRequests)
+		}
+	case abuse.ReasonDuplicate:
+		{
+			ctx.SetStatusCode(fasthttp.StatusConflict)
+		}
+	default:
+		{
+			ctx.SetStatusCode(fasthttp.StatusForbidden)
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package ban
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/identity"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func GetBans(interactor *usecase.BanInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		bans, err := interactor.GetBans(nickname, identity.Token(ctx))
+		write(ctx, fasthttp.StatusOK, bans, err, "User doesn't exist")
+	}
+}
+
+func CreateBan(interactor *usecase.BanInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		data := &ban.Create{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		created, err := interactor.CreateBan(nickname, data, identity.Token(ctx))
+		if invalid.Write(ctx, err) {
+			return
+		}
+
+		write(ctx, fasthttp.StatusCreated, created, err, "User or forum doesn't exist")
+	}
+}
+
+func LiftBan(interactor *usecase.BanInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Ban doesn't exist")
+			return
+		}
+
+		err = interactor.LiftBan(nickname, id, identity.Token(ctx))
+		if err == nil {
+			ctx.SetStatusCode(fasthttp.StatusNoContent)
+			return
+		}
+
+		write(ctx, fasthttp.StatusNoContent, nil, err, "Ban doesn't exist")
+	}
+}
+
+func write(ctx *fasthttp.RequestCtx, status int, result easyjson.Marshaler, err error, notFound string) {
+	switch {
+	case err == nil:
+		{
+			if _, err = easyjson.MarshalToWriter(result, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetStatusCode(status)
+			return
+		}
+	case err == pgx.ErrNoRows:
+		{
+			writeMessage(ctx, fasthttp.StatusNotFound, notFound)
+			return
+		}
+	case err.Error() == "unauthorized":
+		{
+			writeMessage(ctx, fasthttp.StatusUnauthorized, "Moderator token is missing or invalid")
+			return
+		}
+	case err.Error() == "forbidden":
+		{
+			writeMessage(ctx, fasthttp.StatusForbidden, "Not allowed to manage this ban")
+			return
+		}
+	default:
+		{
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func writeMessage(ctx *fasthttp.RequestCtx, status int, description string) {
+	msg := message.Message{
+		Description: description,
+	}
+	if _, err := easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetStatusCode(status)
+}
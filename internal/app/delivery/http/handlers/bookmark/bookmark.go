@@ -0,0 +1,177 @@
+package bookmark
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/bookmark"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func GetBookmarks(interactor *usecase.BookmarkInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		var folder *string
+		if exists := ctx.QueryArgs().Has("folder"); exists {
+			folderRaw := string(ctx.QueryArgs().Peek("folder"))
+			folder = &folderRaw
+		}
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
+		if since != nil {
+			if _, err := strconv.ParseUint(*since, 10, 64); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		bookmarks, err := interactor.GetBookmarks(nickname, folder, limit, since, orderDesc)
+		if err != nil {
+			write(ctx, fasthttp.StatusOK, nil, err, "User doesn't exist")
+			return
+		}
+
+		if pagination.Backward(after) {
+			pagination.Reverse(*bookmarks)
+		}
+
+		window := pagination.NewWindow(after, since != nil, limit != nil && len(*bookmarks) == *limit, len(*bookmarks), func(i int) cursor.Cursor {
+			return cursor.Cursor{Key: strconv.FormatUint((*bookmarks)[i].ID, 10)}
+		})
+		if err = pagination.Write(ctx, bookmarks, window, func() (*page.Total, error) {
+			return interactor.CountBookmarks(nickname, folder)
+		}); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
+
+func CreateBookmark(interactor *usecase.BookmarkInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		data := &bookmark.Create{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		created, err := interactor.CreateBookmark(nickname, data)
+		if invalid.Write(ctx, err) {
+			return
+		}
+
+		if err != nil && err.Error() == "bookmarkAlreadyExists" {
+			write(ctx, fasthttp.StatusConflict, created, nil, "")
+			return
+		}
+
+		write(ctx, fasthttp.StatusCreated, created, err, "User, thread or post doesn't exist")
+	}
+}
+
+func GetFolders(interactor *usecase.BookmarkInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		folders, err := interactor.GetFolders(nickname)
+		write(ctx, fasthttp.StatusOK, folders, err, "User doesn't exist")
+	}
+}
+
+func DeleteBookmark(interactor *usecase.BookmarkInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Bookmark doesn't exist")
+			return
+		}
+
+		err = interactor.DeleteBookmark(nickname, id)
+		if err == nil {
+			ctx.SetStatusCode(fasthttp.StatusNoContent)
+			return
+		}
+
+		write(ctx, fasthttp.StatusNoContent, nil, err, "Bookmark doesn't exist")
+	}
+}
+
+func write(ctx *fasthttp.RequestCtx, status int, result easyjson.Marshaler, err error, notFound string) {
+	switch err {
+	case nil:
+		{
+			if _, err = easyjson.MarshalToWriter(result, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetStatusCode(status)
+			return
+		}
+	case pgx.ErrNoRows:
+		{
+			writeMessage(ctx, fasthttp.StatusNotFound, notFound)
+			return
+		}
+	default:
+		{
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func writeMessage(ctx *fasthttp.RequestCtx, status int, description string) {
+	msg := message.Message{
+		Description: description,
+	}
+	if _, err := easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetStatusCode(status)
+}
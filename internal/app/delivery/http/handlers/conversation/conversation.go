@@ -0,0 +1,196 @@
+package conversation
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/conversation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func Send(interactor *usecase.ConversationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		data := &conversation.Send{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		sent, err := interactor.Send(nickname, data)
+		if invalid.Write(ctx, err) {
+			return
+		}
+
+		write(ctx, fasthttp.StatusCreated, sent, err, "User or conversation doesn't exist")
+	}
+}
+
+func GetConversations(interactor *usecase.ConversationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+		unread := ctx.QueryArgs().GetBool("unread")
+
+		limit, since, orderDesc, after, ok := listParams(ctx)
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		conversations, err := interactor.GetConversations(nickname, limit, since, orderDesc, unread)
+		if err != nil {
+			write(ctx, fasthttp.StatusOK, nil, err, "User doesn't exist")
+			return
+		}
+
+		if pagination.Backward(after) {
+			pagination.Reverse(*conversations)
+		}
+
+		window := pagination.NewWindow(after, since != nil, limit != nil && len(*conversations) == *limit, len(*conversations), func(i int) cursor.Cursor {
+			return cursor.Cursor{Key: strconv.FormatUint((*conversations)[i].ID, 10)}
+		})
+		if err = pagination.Write(ctx, conversations, window, func() (*page.Total, error) {
+			return interactor.CountConversations(nickname, unread)
+		}); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
+
+func GetMessages(interactor *usecase.ConversationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			write(ctx, fasthttp.StatusOK, nil, pgx.ErrNoRows, "Conversation doesn't exist")
+			return
+		}
+
+		limit, since, orderDesc, after, ok := listParams(ctx)
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		messages, err := interactor.GetMessages(nickname, id, limit, since, orderDesc)
+		if err != nil {
+			write(ctx, fasthttp.StatusOK, nil, err, "Conversation doesn't exist")
+			return
+		}
+
+		if pagination.Backward(after) {
+			pagination.Reverse(*messages)
+		}
+
+		window := pagination.NewWindow(after, since != nil, limit != nil && len(*messages) == *limit, len(*messages), func(i int) cursor.Cursor {
+			return cursor.Cursor{Key: strconv.FormatUint((*messages)[i].ID, 10)}
+		})
+		if err = pagination.Write(ctx, messages, window, func() (*page.Total, error) {
+			return interactor.CountMessages(id)
+		}); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
+
+func MarkRead(interactor *usecase.ConversationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			write(ctx, fasthttp.StatusOK, nil, pgx.ErrNoRows, "Conversation doesn't exist")
+			return
+		}
+
+		unread, err := interactor.MarkRead(nickname, id)
+		write(ctx, fasthttp.StatusOK, unread, err, "Conversation doesn't exist")
+	}
+}
+
+// listParams reads limit, since, desc and the page cursor the same way as the post lists
+func listParams(ctx *fasthttp.RequestCtx) (*int, *string, bool, *cursor.Cursor, bool) {
+	var limit *int
+	if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+		limit = &limitRaw
+	}
+
+	var since *string
+	if exists := ctx.QueryArgs().Has("since"); exists {
+		sinceRaw := string(ctx.QueryArgs().Peek("since"))
+		since = &sinceRaw
+	}
+
+	orderDesc := ctx.QueryArgs().GetBool("desc")
+
+	after, err := pagination.Cursor(ctx)
+	if err != nil {
+		return nil, nil, false, nil, false
+	}
+	if after != nil {
+		since = &after.Key
+		orderDesc = orderDesc != after.Backward
+	}
+
+	if since != nil {
+		if _, err := strconv.ParseUint(*since, 10, 64); err != nil {
+			return nil, nil, false, nil, false
+		}
+	}
+
+	return limit, since, orderDesc, after, true
+}
+
+func write(ctx *fasthttp.RequestCtx, status int, result easyjson.Marshaler, err error, notFound string) {
+	switch err {
+	case nil:
+		{
+			if _, err = easyjson.MarshalToWriter(result, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetStatusCode(status)
+			return
+		}
+	case pgx.ErrNoRows:
+		{
+			msg := message.Message{
+				Description: notFound,
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			return
+		}
+	default:
+		{
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -2,9 +2,14 @@ package forum
 
 import (
 	"errors"
+	"strconv"
 
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
 
 	"github.com/jackc/pgx"
@@ -57,12 +62,16 @@ func CreateForum(interactor *usecase.ForumInteractor) fasthttp.RequestHandler {
 		ctx.SetContentType("application/json")
 
 		data := &forum.Create{}
-		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.Title == "" || data.Slug == "" || data.UserNickname == "" {
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
 			ctx.SetStatusCode(fasthttp.StatusBadRequest)
 			return
 		}
 
 		created, err := interactor.CreateForum(data)
+		if invalid.Write(ctx, err) {
+			return
+		}
+
 		switch err {
 		case nil:
 			{
@@ -87,7 +96,7 @@ func CreateForum(interactor *usecase.ForumInteractor) fasthttp.RequestHandler {
 		case pgx.ErrNoRows:
 			{
 				msg := message.Message{
-					Description: "User doesn't exist",
+					Description: "User or parent forum doesn't exist",
 				}
 				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
@@ -130,6 +139,16 @@ func GetForumUsers(interactor *usecase.ForumInteractor) fasthttp.RequestHandler
 
 		orderDesc := ctx.QueryArgs().GetBool("desc")
 
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
 		users, err := interactor.GetForumUsers(slug, limit, since, orderDesc)
 		switch err {
 		case pgx.ErrNoRows:
@@ -147,14 +166,215 @@ func GetForumUsers(interactor *usecase.ForumInteractor) fasthttp.RequestHandler
 			}
 		case nil:
 			{
-				_, err = easyjson.MarshalToWriter(users, ctx.Response.BodyWriter())
+				if pagination.Backward(after) {
+					pagination.Reverse(*users)
+				}
+
+				window := pagination.NewWindow(after, since != nil, limit != nil && len(*users) == *limit, len(*users), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: (*users)[i].Nickname}
+				})
+				if err = pagination.Write(ctx, users, window, func() (*page.Total, error) {
+					return interactor.CountForumUsers(slug)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetForumLeaders(interactor *usecase.ForumInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			if _, err := strconv.ParseInt(after.Key, 10, 32); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		users, err := interactor.GetForumLeaders(slug, limit, since, after)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Forum doesn't exist",
+				}
+				_, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter())
 				if err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 					return
 				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				window := pagination.NewWindow(after, since != nil || after != nil, limit != nil && len(*users) == *limit, len(*users), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: strconv.Itoa(*(*users)[i].Reputation), Name: (*users)[i].Nickname}
+				})
+				window.Prev = nil
+				if err = pagination.Write(ctx, users, window, func() (*page.Total, error) {
+					return interactor.CountForumUsers(slug)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func ListForums(interactor *usecase.ForumInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		sort := string(ctx.QueryArgs().Peek("sort"))
+		switch sort {
+		case "":
+			sort = forum.SortByCreated
+		case forum.SortByTitle, forum.SortByCreated, forum.SortByThreads, forum.SortByPosts:
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		var creator *string
+		if exists := ctx.QueryArgs().Has("creator"); exists {
+			creatorRaw := string(ctx.QueryArgs().Peek("creator"))
+			creator = &creatorRaw
+		}
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if pagination.Backward(after) {
+			orderDesc = !orderDesc
+		}
+
+		// Title cursors carry the title, the others a count or the id
+		if after != nil && sort != forum.SortByTitle {
+			bits := 32
+			if sort == forum.SortByPosts {
+				bits = 64
+			}
+			if _, err := strconv.ParseInt(after.Key, 10, bits); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		forums, err := interactor.ListForums(creator, sort, limit, since, after, orderDesc)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+
+		if pagination.Backward(after) {
+			pagination.Reverse(*forums)
+		}
+
+		window := pagination.NewWindow(after, since != nil || after != nil, limit != nil && len(*forums) == *limit, len(*forums), func(i int) cursor.Cursor {
+			return forumCursor(sort, &(*forums)[i])
+		})
+		if err = pagination.Write(ctx, forums, window, func() (*page.Total, error) {
+			return interactor.CountForums(creator)
+		}); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
+
+func GetForumTree(interactor *usecase.ForumInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		depth, ok := treeDepth(ctx)
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		node, err := interactor.GetForumTree(slug, depth)
+		switch err {
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(node, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
 				ctx.SetStatusCode(fasthttp.StatusOK)
 				return
 			}
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Forum doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
 		default:
 			{
 				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
@@ -163,3 +383,55 @@ func GetForumUsers(interactor *usecase.ForumInteractor) fasthttp.RequestHandler
 		}
 	}
 }
+
+func GetTree(interactor *usecase.ForumInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		depth, ok := treeDepth(ctx)
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		tree, err := interactor.GetTree(depth)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+
+		if _, err = easyjson.MarshalToWriter(tree, ctx.Response.BodyWriter()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
+
+// treeDepth reads the optional ?depth= of the tree routes, 0 returns the roots alone
+func treeDepth(ctx *fasthttp.RequestCtx) (*int, bool) {
+	if !ctx.QueryArgs().Has("depth") {
+		return nil, true
+	}
+
+	depth, err := ctx.QueryArgs().GetUint("depth")
+	if err != nil {
+		return nil, false
+	}
+	return &depth, true
+}
+
+// forumCursor carries the sort value of the forum together with its id as the tiebreaker
+func forumCursor(sort string, received *forum.Forum) cursor.Cursor {
+	switch sort {
+	case forum.SortByTitle:
+		return cursor.Cursor{Key: received.Title, ID: received.ID}
+	case forum.SortByThreads:
+		return cursor.Cursor{Key: strconv.Itoa(received.Threads), ID: received.ID}
+	case forum.SortByPosts:
+		return cursor.Cursor{Key: strconv.FormatInt(received.Posts, 10), ID: received.ID}
+	default:
+		return cursor.Cursor{Key: strconv.FormatUint(received.ID, 10), ID: received.ID}
+	}
+}
@@ -0,0 +1,272 @@
+package moderation
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/identity"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/moderation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+// conflicts maps repository and interactor errors to 401, 403 and 409 answers
+var conflicts = map[string]struct {
+	status      int
+	description string
+}{
+	"authorBanned":           {fasthttp.StatusConflict, "Author is banned from this forum, the post can't be published"},
+	"forbidden":              {fasthttp.StatusForbidden, "Not allowed to moderate this forum"},
+	"postAlreadyReported":    {fasthttp.StatusConflict, "Post is already reported by this user"},
+	"postHasReplies":         {fasthttp.StatusConflict, "Post has replies, hide it instead"},
+	"postParentDoesNotExist": {fasthttp.StatusConflict, "Post parent doesn't exist"},
+	"unauthorized":           {fasthttp.StatusUnauthorized, "Moderator token is missing or invalid"},
+}
+
+func ReportPost(interactor *usecase.ModerationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Post doesn't exist")
+			return
+		}
+
+		data := &moderation.Report{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		item, err := interactor.ReportPost(id, data)
+		if invalid.Write(ctx, err) {
+			return
+		}
+
+		write(ctx, fasthttp.StatusCreated, item, err, "Post or user doesn't exist")
+	}
+}
+
+func GetModerators(interactor *usecase.ModerationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		moderators, err := interactor.GetModerators(slug)
+		write(ctx, fasthttp.StatusOK, moderators, err, "Forum doesn't exist")
+	}
+}
+
+func AddModerator(interactor *usecase.ModerationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		data := &moderation.Moderator{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.Nickname == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		moderator, err := interactor.AddModerator(slug, data.Nickname, identity.Token(ctx))
+		write(ctx, fasthttp.StatusCreated, moderator, err, "Forum or user doesn't exist")
+	}
+}
+
+func RemoveModerator(interactor *usecase.ModerationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+		nickname := ctx.UserValue("nickname").(string)
+
+		err := interactor.RemoveModerator(slug, nickname, identity.Token(ctx))
+		if err == nil {
+			ctx.SetStatusCode(fasthttp.StatusNoContent)
+			return
+		}
+
+		write(ctx, fasthttp.StatusNoContent, nil, err, "Forum or moderator doesn't exist")
+	}
+}
+
+func GetQueue(interactor *usecase.ModerationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		limit, since, orderDesc, after, ok := listParams(ctx)
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		items, err := interactor.GetQueue(slug, identity.Token(ctx), limit, since, orderDesc)
+		if err != nil {
+			write(ctx, fasthttp.StatusOK, nil, err, "Forum doesn't exist")
+			return
+		}
+
+		if pagination.Backward(after) {
+			pagination.Reverse(*items)
+		}
+
+		window := pagination.NewWindow(after, since != nil, limit != nil && len(*items) == *limit, len(*items), func(i int) cursor.Cursor {
+			return cursor.Cursor{Key: strconv.FormatUint((*items)[i].ID, 10)}
+		})
+		if err = pagination.Write(ctx, items, window, func() (*page.Total, error) {
+			return interactor.CountQueue(slug)
+		}); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
+
+func Resolve(interactor *usecase.ModerationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Queue item doesn't exist")
+			return
+		}
+
+		data := &moderation.Decision{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		action, err := interactor.Resolve(slug, id, data, identity.Token(ctx))
+		if invalid.Write(ctx, err) {
+			return
+		}
+
+		write(ctx, fasthttp.StatusOK, action, err, "Forum, queue item or post doesn't exist")
+	}
+}
+
+func GetActions(interactor *usecase.ModerationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		limit, since, orderDesc, after, ok := listParams(ctx)
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		actions, err := interactor.GetActions(slug, identity.Token(ctx), limit, since, orderDesc)
+		if err != nil {
+			write(ctx, fasthttp.StatusOK, nil, err, "Forum doesn't exist")
+			return
+		}
+
+		if pagination.Backward(after) {
+			pagination.Reverse(*actions)
+		}
+
+		window := pagination.NewWindow(after, since != nil, limit != nil && len(*actions) == *limit, len(*actions), func(i int) cursor.Cursor {
+			return cursor.Cursor{Key: strconv.FormatUint((*actions)[i].ID, 10)}
+		})
+		if err = pagination.Write(ctx, actions, window, func() (*page.Total, error) {
+			return interactor.CountActions(slug)
+		}); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
+
+// listParams reads limit, since, desc and the page cursor shared by the queue and the action log
+func listParams(ctx *fasthttp.RequestCtx) (*int, *string, bool, *cursor.Cursor, bool) {
+	var limit *int
+	if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+		limit = &limitRaw
+	}
+
+	var since *string
+	if exists := ctx.QueryArgs().Has("since"); exists {
+		sinceRaw := string(ctx.QueryArgs().Peek("since"))
+		since = &sinceRaw
+	}
+
+	orderDesc := ctx.QueryArgs().GetBool("desc")
+
+	after, err := pagination.Cursor(ctx)
+	if err != nil {
+		return nil, nil, false, nil, false
+	}
+	if after != nil {
+		since = &after.Key
+		orderDesc = orderDesc != after.Backward
+	}
+
+	if since != nil {
+		if _, err := strconv.ParseUint(*since, 10, 64); err != nil {
+			return nil, nil, false, nil, false
+		}
+	}
+
+	return limit, since, orderDesc, after, true
+}
+
+func write(ctx *fasthttp.RequestCtx, status int, result easyjson.Marshaler, err error, notFound string) {
+	switch {
+	case err == nil:
+		{
+			if _, err = easyjson.MarshalToWriter(result, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetStatusCode(status)
+			return
+		}
+	case err == pgx.ErrNoRows:
+		{
+			writeMessage(ctx, fasthttp.StatusNotFound, notFound)
+			return
+		}
+	default:
+		{
+			if conflict, ok := conflicts[err.Error()]; ok {
+				writeMessage(ctx, conflict.status, conflict.description)
+				return
+			}
+
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func writeMessage(ctx *fasthttp.RequestCtx, status int, description string) {
+	msg := message.Message{
+		Description: description,
+	}
+	if _, err := easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetStatusCode(status)
+}
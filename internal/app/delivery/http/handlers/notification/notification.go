@@ -0,0 +1,215 @@
+package notification
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/notification"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func SubscribeThread(interactor *usecase.NotificationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slugOrId := ctx.UserValue("slug_or_id").(string)
+
+		data := &notification.Subscription{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		subscription, err := interactor.SubscribeThread(slugOrId, data.UserNickname)
+		write(ctx, subscription, err, "User or thread doesn't exist")
+	}
+}
+
+func UnsubscribeThread(interactor *usecase.NotificationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slugOrId := ctx.UserValue("slug_or_id").(string)
+
+		data := &notification.Subscription{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		subscription, err := interactor.UnsubscribeThread(slugOrId, data.UserNickname)
+		write(ctx, subscription, err, "Thread or subscription doesn't exist")
+	}
+}
+
+func SubscribeForum(interactor *usecase.NotificationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		data := &notification.Subscription{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		subscription, err := interactor.SubscribeForum(slug, data.UserNickname)
+		write(ctx, subscription, err, "User or forum doesn't exist")
+	}
+}
+
+func UnsubscribeForum(interactor *usecase.NotificationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		data := &notification.Subscription{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		subscription, err := interactor.UnsubscribeForum(slug, data.UserNickname)
+		write(ctx, subscription, err, "Forum or subscription doesn't exist")
+	}
+}
+
+func GetNotifications(interactor *usecase.NotificationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+		unread := ctx.QueryArgs().GetBool("unread")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
+		if since != nil {
+			if _, err := strconv.ParseUint(*since, 10, 64); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		notifications, err := interactor.GetNotifications(nickname, limit, since, orderDesc, unread)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if pagination.Backward(after) {
+					pagination.Reverse(*notifications)
+				}
+
+				window := pagination.NewWindow(after, since != nil, limit != nil && len(*notifications) == *limit, len(*notifications), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: strconv.FormatUint((*notifications)[i].ID, 10)}
+				})
+				if err = pagination.Write(ctx, notifications, window, func() (*page.Total, error) {
+					return interactor.CountNotifications(nickname, unread)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+// MarkRead marks the listed notifications as read, an empty body marks the whole inbox
+func MarkRead(interactor *usecase.NotificationInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		data := &notification.MarkRead{}
+		if body := ctx.PostBody(); len(body) != 0 {
+			if err := data.UnmarshalJSON(body); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		unread, err := interactor.MarkRead(nickname, data.IDs)
+		write(ctx, unread, err, "User doesn't exist")
+	}
+}
+
+func write(ctx *fasthttp.RequestCtx, result easyjson.Marshaler, err error, notFound string) {
+	switch err {
+	case pgx.ErrNoRows:
+		{
+			msg := message.Message{
+				Description: notFound,
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			return
+		}
+	case nil:
+		{
+			if _, err = easyjson.MarshalToWriter(result, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetStatusCode(fasthttp.StatusOK)
+			return
+		}
+	default:
+		{
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -1,9 +1,17 @@
 package post
 
 import (
+	"strconv"
 	"strings"
 
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/format"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/guard"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/identity"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
 
@@ -40,6 +48,10 @@ func GetPost(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 			}
 		case nil:
 			{
+				if format.HTML(ctx) {
+					interactor.RenderPost(&info.Post)
+				}
+
 				if _, err = easyjson.MarshalToWriter(info, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 					return
@@ -70,12 +82,28 @@ func UpdatePost(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 		}
 		data.ID = id
 
-		updated, err := interactor.UpdatePost(data)
+		updated, err := interactor.UpdatePost(data, identity.Token(ctx))
+		if invalid.Write(ctx, err) || guard.Write(ctx, err) {
+			return
+		}
+
+		if err != nil && err.Error() == "unauthorized" {
+			msg := message.Message{
+				Description: "Editor token is invalid",
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+			ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+			return
+		}
+
 		switch err {
 		case pgx.ErrNoRows:
 			{
 				msg := message.Message{
-					Description: "Post doesn't exist",
+					Description: "Post or editor doesn't exist",
 				}
 				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
@@ -86,6 +114,10 @@ func UpdatePost(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 			}
 		case nil:
 			{
+				if format.HTML(ctx) {
+					interactor.RenderPost(updated)
+				}
+
 				if _, err = easyjson.MarshalToWriter(updated, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 					return
@@ -116,14 +148,30 @@ func CreatePosts(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 		}
 
 		posts, err := interactor.CreatePosts(newPosts, slugOrId)
+		if invalid.Write(ctx, err) || guard.Write(ctx, err) {
+			return
+		}
+
 		switch {
 		case err == nil:
 			{
+				if format.HTML(ctx) {
+					interactor.RenderPosts(*posts)
+				}
+
 				if _, err := easyjson.MarshalToWriter(posts, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 					return
 				}
 
+				// Quarantined posts are held for moderators, so the batch is only partly created
+				for _, created := range *newPosts {
+					if created.Quarantine != "" {
+						ctx.SetStatusCode(fasthttp.StatusAccepted)
+						return
+					}
+				}
+
 				ctx.SetStatusCode(fasthttp.StatusCreated)
 				return
 			}
@@ -179,10 +227,40 @@ func GetPosts(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 
 		orderDesc := ctx.QueryArgs().GetBool("desc")
 
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
 		sort := string(ctx.QueryArgs().Peek("sort"))
+		reversible := sort != "parent_tree" && sort != "top"
+
+		if after != nil {
+			if after.Backward && !reversible {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+			if reversible {
+				since = &after.Key
+			}
+			orderDesc = orderDesc != after.Backward
+
+			// Keys are post ids, or the root votes for the top sort
+			if _, err := strconv.ParseInt(after.Key, 10, 32); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		if since != nil {
+			if _, err := strconv.ParseUint(*since, 10, 32); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
 
 		var posts *post.Posts
-		var err error
 		switch sort {
 		case "flat":
 			{
@@ -194,7 +272,11 @@ func GetPosts(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 			}
 		case "parent_tree":
 			{
-				posts, err = interactor.GetPostsParentTree(slugOrId, limit, since, orderDesc)
+				posts, err = interactor.GetPostsParentTree(slugOrId, limit, since, after, orderDesc)
+			}
+		case "top":
+			{
+				posts, err = interactor.GetPostsTop(slugOrId, limit, since, after, orderDesc)
 			}
 		default:
 			{
@@ -227,7 +309,35 @@ func GetPosts(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 			posts = &post.Posts{}
 		}
 
-		if _, err = easyjson.MarshalToWriter(posts, ctx.Response.BodyWriter()); err != nil {
+		if format.HTML(ctx) {
+			interactor.RenderPosts(*posts)
+		}
+
+		// Tree sorts limit the number of root posts rather than rows and cannot be walked backward
+		size := len(*posts)
+		if !reversible {
+			size = 0
+			for _, row := range *posts {
+				if row.Parent == 0 {
+					size++
+				}
+			}
+		} else if pagination.Backward(after) {
+			pagination.Reverse(*posts)
+		}
+
+		window := pagination.NewWindow(after, since != nil || after != nil, limit != nil && size == *limit, len(*posts), func(i int) cursor.Cursor {
+			if reversible {
+				return cursor.Cursor{Key: strconv.FormatUint((*posts)[i].ID, 10)}
+			}
+			return rootCursor(sort, *posts, i)
+		})
+		if !reversible {
+			window.Prev = nil
+		}
+		if err = pagination.Write(ctx, posts, window, func() (*page.Total, error) {
+			return interactor.CountPosts(slugOrId)
+		}); err != nil {
 			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 			return
 		}
@@ -235,3 +345,284 @@ func GetPosts(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
 		ctx.SetStatusCode(fasthttp.StatusOK)
 	}
 }
+
+// rootCursor continues a tree sort after the root post owning the row,
+// top posts carry the score of the root with its id as the tiebreaker
+func rootCursor(sort string, posts post.Posts, i int) cursor.Cursor {
+	for i > 0 && posts[i].Parent != 0 {
+		i--
+	}
+
+	root := posts[i]
+	if sort == "top" {
+		return cursor.Cursor{Key: strconv.Itoa(root.Votes), ID: root.ID}
+	}
+	return cursor.Cursor{Key: strconv.FormatUint(root.ID, 10), ID: root.ID}
+}
+
+func GetPostHistory(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		id := ctx.UserValue("id").(string)
+
+		revisions, err := interactor.GetPostHistory(id)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Post doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(revisions, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetPostDiff(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		id := ctx.UserValue("id").(string)
+		if _, err := strconv.ParseUint(id, 10, 32); err != nil {
+			msg := message.Message{
+				Description: "Post doesn't exist",
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			return
+		}
+
+		fromRaw, err := ctx.QueryArgs().GetUint("from")
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		from := uint64(fromRaw)
+
+		var to *uint64
+		if exists := ctx.QueryArgs().Has("to"); exists {
+			toRaw, err := ctx.QueryArgs().GetUint("to")
+			if err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+			toValue := uint64(toRaw)
+			to = &toValue
+		}
+
+		diff, err := interactor.GetPostDiff(id, from, to)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Post or revision doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(diff, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetUserPosts(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
+		if since != nil {
+			if _, err := strconv.ParseUint(*since, 10, 32); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		posts, err := interactor.GetUserPosts(nickname, limit, since, orderDesc)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if format.HTML(ctx) {
+					interactor.RenderPosts(*posts)
+				}
+
+				if pagination.Backward(after) {
+					pagination.Reverse(*posts)
+				}
+
+				window := pagination.NewWindow(after, since != nil, limit != nil && len(*posts) == *limit, len(*posts), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: strconv.FormatUint((*posts)[i].ID, 10)}
+				})
+				if err = pagination.Write(ctx, posts, window, func() (*page.Total, error) {
+					return interactor.CountUserPosts(nickname)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetUserMentions(interactor *usecase.PostInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
+		if since != nil {
+			if _, err := strconv.ParseUint(*since, 10, 32); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		posts, err := interactor.GetUserMentions(nickname, limit, since, orderDesc)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if format.HTML(ctx) {
+					interactor.RenderPosts(*posts)
+				}
+
+				if pagination.Backward(after) {
+					pagination.Reverse(*posts)
+				}
+
+				window := pagination.NewWindow(after, since != nil, limit != nil && len(*posts) == *limit, len(*posts), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: strconv.FormatUint((*posts)[i].ID, 10)}
+				})
+				if err = pagination.Write(ctx, posts, window, func() (*page.Total, error) {
+					return interactor.CountUserMentions(nickname)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package search
+
+import (
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/search"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+func optionalArg(ctx *fasthttp.RequestCtx, key string) *string {
+	if exists := ctx.QueryArgs().Has(key); !exists {
+		return nil
+	}
+	value := string(ctx.QueryArgs().Peek(key))
+	return &value
+}
+
+func optionalTime(ctx *fasthttp.RequestCtx, key string) (*time.Time, error) {
+	raw := optionalArg(ctx, key)
+	if raw == nil {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, *raw)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func Search(interactor *usecase.SearchInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		query := &search.Query{
+			Text:   string(ctx.QueryArgs().Peek("q")),
+			Forum:  optionalArg(ctx, "forum"),
+			Thread: optionalArg(ctx, "thread"),
+			Author: optionalArg(ctx, "author"),
+			Limit:  defaultLimit,
+		}
+		if query.Text == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			query.Limit = limitRaw
+			if query.Limit > maxLimit {
+				query.Limit = maxLimit
+			}
+		}
+
+		var err error
+		if query.From, err = optionalTime(ctx, "from"); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if query.To, err = optionalTime(ctx, "to"); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		if query.After, err = pagination.Cursor(ctx); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		results, err := interactor.Search(query)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Thread doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case cursor.ErrInvalid:
+			{
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		case nil:
+			{
+				if results.Cursor != nil {
+					ctx.Response.Header.Add("Link", pagination.Link(ctx, *results.Cursor, "next"))
+				}
+				if _, err = easyjson.MarshalToWriter(results, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
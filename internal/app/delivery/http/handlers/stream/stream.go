@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"bufio"
+	"strconv"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+// keepAlive is how often an idle stream sends a comment, it also detects disconnected clients
+const keepAlive = 15 * time.Second
+
+func ThreadStream(interactor *usecase.StreamInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		slugOrId := ctx.UserValue("slug_or_id").(string)
+
+		events, cancel, err := interactor.SubscribeThread(slugOrId)
+		serve(ctx, events, cancel, err, "Thread doesn't exist")
+	}
+}
+
+func ForumStream(interactor *usecase.StreamInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		slug := ctx.UserValue("slug").(string)
+
+		events, cancel, err := interactor.SubscribeForum(slug)
+		serve(ctx, events, cancel, err, "Forum doesn't exist")
+	}
+}
+
+func serve(ctx *fasthttp.RequestCtx, events <-chan *event.Event, cancel func(), err error, notFound string) {
+	switch err {
+	case pgx.ErrNoRows:
+		{
+			ctx.SetContentType("application/json")
+			msg := message.Message{
+				Description: notFound,
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			return
+		}
+	case nil:
+		{
+			ctx.SetContentType("text/event-stream")
+			ctx.Response.Header.Set("Cache-Control", "no-cache")
+			ctx.SetStatusCode(fasthttp.StatusOK)
+			ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+				defer cancel()
+				write(w, events)
+			})
+			return
+		}
+	default:
+		{
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// write sends events until the client goes away or the bus drops the subscription as too slow
+func write(w *bufio.Writer, events <-chan *event.Event) {
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := e.MarshalJSON()
+			if err != nil {
+				continue
+			}
+			w.WriteString("id: " + strconv.FormatUint(e.ID, 10) + "\nevent: " + e.Type + "\ndata: ")
+			w.Write(data)
+			w.WriteString("\n\n")
+		case <-ticker.C:
+			w.WriteString(": keep-alive\n\n")
+		}
+
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
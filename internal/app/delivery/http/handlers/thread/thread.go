@@ -1,7 +1,16 @@
 package thread
 
 import (
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/format"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/guard"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/identity"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
 	"github.com/jackc/pgx"
@@ -32,6 +41,10 @@ func GetThread(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler {
 			}
 		case nil:
 			{
+				if format.HTML(ctx) {
+					interactor.RenderThread(thread)
+				}
+
 				if _, err = easyjson.MarshalToWriter(thread, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 					return
@@ -66,7 +79,28 @@ func GetThreads(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler {
 		}
 		orderDesc := ctx.QueryArgs().GetBool("desc")
 
-		threads, err := interactor.GetThreads(slug, limit, since, orderDesc)
+		var tag *string
+		if exists := ctx.QueryArgs().Has("tag"); exists {
+			tagRaw := string(ctx.QueryArgs().Peek("tag"))
+			tag = &tagRaw
+		}
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if pagination.Backward(after) {
+			orderDesc = !orderDesc
+		}
+		if after != nil {
+			if _, err := time.Parse(time.RFC3339Nano, after.Key); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		threads, err := interactor.GetThreads(slug, tag, limit, since, after, orderDesc)
 		switch err {
 		case pgx.ErrNoRows:
 			{
@@ -82,7 +116,20 @@ func GetThreads(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler {
 			}
 		case nil:
 			{
-				if _, err = easyjson.MarshalToWriter(threads, ctx.Response.BodyWriter()); err != nil {
+				if format.HTML(ctx) {
+					interactor.RenderThreads(*threads)
+				}
+
+				if pagination.Backward(after) {
+					pagination.Reverse(*threads)
+				}
+
+				window := pagination.NewWindow(after, since != nil || after != nil, limit != nil && len(*threads) == *limit, len(*threads), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: (*threads)[i].Created.Format(time.RFC3339Nano), ID: (*threads)[i].ID}
+				})
+				if err = pagination.Write(ctx, threads, window, func() (*page.Total, error) {
+					return interactor.CountThreads(slug, tag)
+				}); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 					return
 				}
@@ -105,13 +152,29 @@ func CreateThread(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler
 		forumSlug := ctx.UserValue("slug").(string)
 
 		data := &thread.Create{}
-		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.Title == "" || data.Message == "" || data.UserNickname == "" {
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
 			ctx.SetStatusCode(fasthttp.StatusBadRequest)
 			return
 		}
 		data.ForumSlug = forumSlug
 
 		received, err := interactor.CreateThread(data)
+		if invalid.Write(ctx, err) || guard.Write(ctx, err) {
+			return
+		}
+
+		if err != nil && err.Error() == "forumIsCategory" {
+			msg := message.Message{
+				Description: "Forum is a category, threads belong in its subforums",
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+			ctx.SetStatusCode(fasthttp.StatusConflict)
+			return
+		}
+
 		switch err {
 		case pgx.ErrNoRows:
 			{
@@ -128,6 +191,10 @@ func CreateThread(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler
 			}
 		case nil:
 			{
+				if format.HTML(ctx) {
+					interactor.RenderThread(received)
+				}
+
 				_, err = easyjson.MarshalToWriter(received, ctx.Response.BodyWriter())
 				if err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
@@ -163,12 +230,28 @@ func UpdateThread(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler
 			return
 		}
 
-		updated, err := interactor.UpdateThread(&data, slugOrId)
+		updated, err := interactor.UpdateThread(&data, slugOrId, identity.Token(ctx))
+		if invalid.Write(ctx, err) || guard.Write(ctx, err) {
+			return
+		}
+
+		if err != nil && err.Error() == "unauthorized" {
+			msg := message.Message{
+				Description: "Editor token is invalid",
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+			ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+			return
+		}
+
 		switch err {
 		case pgx.ErrNoRows:
 			{
 				msg := message.Message{
-					Description: "Thread doesn't exist",
+					Description: "Thread or editor doesn't exist",
 				}
 				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
@@ -179,6 +262,10 @@ func UpdateThread(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler
 			}
 		case nil:
 			{
+				if format.HTML(ctx) {
+					interactor.RenderThread(updated)
+				}
+
 				if _, err = easyjson.MarshalToWriter(updated, ctx.Response.BodyWriter()); err != nil {
 					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
 					return
@@ -194,3 +281,230 @@ func UpdateThread(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler
 		}
 	}
 }
+
+func GetThreadHistory(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slugOrId := ctx.UserValue("slug_or_id").(string)
+
+		revisions, err := interactor.GetThreadHistory(slugOrId)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Thread doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(revisions, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetUserThreads(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if pagination.Backward(after) {
+			orderDesc = !orderDesc
+		}
+		if after != nil {
+			if _, err := time.Parse(time.RFC3339Nano, after.Key); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		threads, err := interactor.GetUserThreads(nickname, limit, since, after, orderDesc)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if format.HTML(ctx) {
+					interactor.RenderThreads(*threads)
+				}
+
+				if pagination.Backward(after) {
+					pagination.Reverse(*threads)
+				}
+
+				window := pagination.NewWindow(after, since != nil || after != nil, limit != nil && len(*threads) == *limit, len(*threads), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: (*threads)[i].Created.Format(time.RFC3339Nano), ID: (*threads)[i].ID}
+				})
+				if err = pagination.Write(ctx, threads, window, func() (*page.Total, error) {
+					return interactor.CountUserThreads(nickname)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetTagThreads(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		tag := ctx.UserValue("tag").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if pagination.Backward(after) {
+			orderDesc = !orderDesc
+		}
+		if after != nil {
+			if _, err := time.Parse(time.RFC3339Nano, after.Key); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		threads, err := interactor.GetTagThreads(tag, limit, since, after, orderDesc)
+		switch err {
+		case nil:
+			{
+				if format.HTML(ctx) {
+					interactor.RenderThreads(*threads)
+				}
+
+				if pagination.Backward(after) {
+					pagination.Reverse(*threads)
+				}
+
+				window := pagination.NewWindow(after, since != nil || after != nil, limit != nil && len(*threads) == *limit, len(*threads), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: (*threads)[i].Created.Format(time.RFC3339Nano), ID: (*threads)[i].ID}
+				})
+				if err = pagination.Write(ctx, threads, window, func() (*page.Total, error) {
+					return interactor.CountTagThreads(tag)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetForumTags(interactor *usecase.ThreadInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		tags, err := interactor.GetForumTags(slug, limit)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Forum doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(tags, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
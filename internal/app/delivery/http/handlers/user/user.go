@@ -1,7 +1,14 @@
 package user
 
 import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/identity"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
 	"github.com/jackc/pgx"
@@ -14,8 +21,19 @@ func GetUserByNickname(interactor *usecase.UserInteractor) fasthttp.RequestHandl
 		ctx.SetContentType("application/json")
 
 		nickname := ctx.UserValue("nickname").(string)
+		received, err := interactor.GetProfile(nickname, identity.Token(ctx))
+		if err != nil && err.Error() == "unauthorized" {
+			msg := message.Message{
+				Description: "Moderator token is invalid",
+			}
+			if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+			ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+			return
+		}
 
-		received, err := interactor.GetUserByNickname(nickname)
 		switch err {
 		case nil:
 			{
@@ -59,6 +77,10 @@ func UpdateUser(interactor *usecase.UserInteractor) fasthttp.RequestHandler {
 		nickname := ctx.UserValue("nickname").(string)
 
 		updated, err := interactor.UpdateUser(data, nickname)
+		if invalid.Write(ctx, err) {
+			return
+		}
+
 		switch err {
 		case nil:
 			{
@@ -102,13 +124,17 @@ func CreateUser(interactor *usecase.UserInteractor) fasthttp.RequestHandler {
 		ctx.SetContentType("application/json")
 
 		data := &user.User{}
-		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.Email == "" || data.Fullname == "" {
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
 			ctx.SetStatusCode(fasthttp.StatusBadRequest)
 			return
 		}
 		data.Nickname = ctx.UserValue("nickname").(string)
 
 		users, err := interactor.CreateUser(data)
+		if invalid.Write(ctx, err) {
+			return
+		}
+
 		switch {
 		case err == nil:
 			{
@@ -137,3 +163,81 @@ func CreateUser(interactor *usecase.UserInteractor) fasthttp.RequestHandler {
 		}
 	}
 }
+
+func GetUsers(interactor *usecase.UserInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		var query *string
+		if exists := ctx.QueryArgs().Has("q"); exists {
+			queryRaw := string(ctx.QueryArgs().Peek("q"))
+			query = &queryRaw
+		}
+
+		sort := string(ctx.QueryArgs().Peek("sort"))
+		switch sort {
+		case "":
+			sort = user.SortByNickname
+		case user.SortByNickname, user.SortByJoined:
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if pagination.Backward(after) {
+			orderDesc = !orderDesc
+		}
+
+		// Join order cursors carry the user id
+		if after != nil && sort == user.SortByJoined {
+			if _, err := strconv.ParseUint(after.Key, 10, 64); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		users, err := interactor.GetUsers(query, sort, limit, since, after, orderDesc)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+
+		if pagination.Backward(after) {
+			pagination.Reverse(*users)
+		}
+
+		window := pagination.NewWindow(after, since != nil || after != nil, limit != nil && len(*users) == *limit, len(*users), func(i int) cursor.Cursor {
+			if sort == user.SortByJoined {
+				return cursor.Cursor{Key: strconv.FormatUint((*users)[i].ID, 10)}
+			}
+			return cursor.Cursor{Key: (*users)[i].Nickname}
+		})
+		if err = pagination.Write(ctx, users, window, func() (*page.Total, error) {
+			return interactor.CountUsers(query)
+		}); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+	}
+}
@@ -1,7 +1,14 @@
 package vote
 
 import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/guard"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/vote"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
 	"github.com/jackc/pgx"
@@ -35,6 +42,10 @@ func CreateVote(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
 		}
 
 		thread, err := interactor.CreateVote(data, slugOrId)
+		if guard.Write(ctx, err) {
+			return
+		}
+
 		switch err {
 		case pgx.ErrNoRows:
 			{
@@ -67,3 +78,415 @@ func CreateVote(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
 		}
 	}
 }
+
+func DeleteVote(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slugOrId := ctx.UserValue("slug_or_id").(string)
+
+		data := &vote.Vote{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		thread, err := interactor.DeleteVote(data, slugOrId)
+		if guard.Write(ctx, err) {
+			return
+		}
+
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User, thread or vote doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(thread, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetThreadVotes(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slugOrId := ctx.UserValue("slug_or_id").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
+		votes, err := interactor.GetThreadVotes(slugOrId, limit, since, orderDesc)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "Thread doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if pagination.Backward(after) {
+					pagination.Reverse(*votes)
+				}
+
+				window := pagination.NewWindow(after, since != nil, limit != nil && len(*votes) == *limit, len(*votes), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: (*votes)[i].UserNickname}
+				})
+				if err = pagination.Write(ctx, votes, window, func() (*page.Total, error) {
+					return interactor.CountThreadVotes(slugOrId)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetUserVotes(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		nickname := ctx.UserValue("nickname").(string)
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
+		if since != nil {
+			if _, err := strconv.ParseUint(*since, 10, 32); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		votes, err := interactor.GetUserVotes(nickname, limit, since, orderDesc)
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if pagination.Backward(after) {
+					pagination.Reverse(*votes)
+				}
+
+				window := pagination.NewWindow(after, since != nil, limit != nil && len(*votes) == *limit, len(*votes), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: strconv.FormatUint((*votes)[i].ThreadID, 10)}
+				})
+				if err = pagination.Write(ctx, votes, window, func() (*page.Total, error) {
+					return interactor.CountUserVotes(nickname)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func CreatePostVote(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		id := ctx.UserValue("id").(string)
+
+		data := &vote.Vote{}
+		err := data.UnmarshalJSON(ctx.PostBody())
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		switch data.Rating {
+		case 1:
+			data.Voice = true
+		case -1:
+			data.Voice = false
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		post, err := interactor.CreatePostVote(data, id)
+		if guard.Write(ctx, err) {
+			return
+		}
+
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User or post doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(post, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func DeletePostVote(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		id := ctx.UserValue("id").(string)
+
+		data := &vote.Vote{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		post, err := interactor.DeletePostVote(data, id)
+		if guard.Write(ctx, err) {
+			return
+		}
+
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User, post or vote doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(post, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func CreateReaction(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		id := ctx.UserValue("id").(string)
+
+		data := &vote.Reaction{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" || data.Reaction == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		post, err := interactor.CreateReaction(data, id)
+		if invalid.Write(ctx, err) || guard.Write(ctx, err) {
+			return
+		}
+
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User or post doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(post, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func DeleteReaction(interactor *usecase.VoteInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		id := ctx.UserValue("id").(string)
+
+		data := &vote.Reaction{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil || data.UserNickname == "" || data.Reaction == "" {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		post, err := interactor.DeleteReaction(data, id)
+		if invalid.Write(ctx, err) || guard.Write(ctx, err) {
+			return
+		}
+
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				msg := message.Message{
+					Description: "User or post doesn't exist",
+				}
+				if _, err = easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusNotFound)
+				return
+			}
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(post, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
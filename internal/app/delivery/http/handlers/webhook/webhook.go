@@ -0,0 +1,329 @@
+package webhook
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/identity"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/invalid"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/delivery/http/pagination"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/message"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/webhook"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase"
+
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+func CreateWebhook(interactor *usecase.WebhookInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		data := &webhook.Create{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		created, err := interactor.CreateWebhook(slug, data, identity.Token(ctx))
+		if invalid.Write(ctx, err) || denied(ctx, err) {
+			return
+		}
+
+		switch err {
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(created, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusCreated)
+				return
+			}
+		case pgx.ErrNoRows:
+			{
+				writeMessage(ctx, fasthttp.StatusNotFound, "Forum doesn't exist")
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetWebhooks(interactor *usecase.WebhookInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+
+		webhooks, err := interactor.GetWebhooks(slug, identity.Token(ctx))
+		if denied(ctx, err) {
+			return
+		}
+
+		switch err {
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(webhooks, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		case pgx.ErrNoRows:
+			{
+				writeMessage(ctx, fasthttp.StatusNotFound, "Forum doesn't exist")
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetWebhook(interactor *usecase.WebhookInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+			return
+		}
+
+		received, err := interactor.GetWebhook(slug, id, identity.Token(ctx))
+		if denied(ctx, err) {
+			return
+		}
+
+		switch err {
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(received, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		case pgx.ErrNoRows:
+			{
+				writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func UpdateWebhook(interactor *usecase.WebhookInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+			return
+		}
+
+		data := &webhook.Update{}
+		if err := data.UnmarshalJSON(ctx.PostBody()); err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+
+		updated, err := interactor.UpdateWebhook(slug, id, data, identity.Token(ctx))
+		if invalid.Write(ctx, err) || denied(ctx, err) {
+			return
+		}
+
+		switch err {
+		case nil:
+			{
+				if _, err = easyjson.MarshalToWriter(updated, ctx.Response.BodyWriter()); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		case pgx.ErrNoRows:
+			{
+				writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func DeleteWebhook(interactor *usecase.WebhookInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+			return
+		}
+
+		err = interactor.DeleteWebhook(slug, id, identity.Token(ctx))
+		if denied(ctx, err) {
+			return
+		}
+
+		switch err {
+		case nil:
+			{
+				ctx.SetStatusCode(fasthttp.StatusNoContent)
+				return
+			}
+		case pgx.ErrNoRows:
+			{
+				writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+func GetDeliveries(interactor *usecase.WebhookInteractor) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+
+		slug := ctx.UserValue("slug").(string)
+		id, err := strconv.ParseUint(ctx.UserValue("id").(string), 10, 64)
+		if err != nil {
+			writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+			return
+		}
+
+		var limit *int
+		if limitRaw := ctx.QueryArgs().GetUintOrZero("limit"); limitRaw != 0 {
+			limit = &limitRaw
+		}
+
+		var since *string
+		if exists := ctx.QueryArgs().Has("since"); exists {
+			sinceRaw := string(ctx.QueryArgs().Peek("since"))
+			since = &sinceRaw
+		}
+
+		orderDesc := ctx.QueryArgs().GetBool("desc")
+
+		after, err := pagination.Cursor(ctx)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			return
+		}
+		if after != nil {
+			since = &after.Key
+			orderDesc = orderDesc != after.Backward
+		}
+
+		if since != nil {
+			if _, err := strconv.ParseUint(*since, 10, 64); err != nil {
+				ctx.SetStatusCode(fasthttp.StatusBadRequest)
+				return
+			}
+		}
+
+		deliveries, err := interactor.GetDeliveries(slug, id, identity.Token(ctx), limit, since, orderDesc)
+		if denied(ctx, err) {
+			return
+		}
+
+		switch err {
+		case pgx.ErrNoRows:
+			{
+				writeMessage(ctx, fasthttp.StatusNotFound, "Webhook doesn't exist")
+				return
+			}
+		case nil:
+			{
+				if pagination.Backward(after) {
+					pagination.Reverse(*deliveries)
+				}
+
+				window := pagination.NewWindow(after, since != nil, limit != nil && len(*deliveries) == *limit, len(*deliveries), func(i int) cursor.Cursor {
+					return cursor.Cursor{Key: strconv.FormatUint((*deliveries)[i].ID, 10)}
+				})
+				if err = pagination.Write(ctx, deliveries, window, func() (*page.Total, error) {
+					return interactor.CountDeliveries(id)
+				}); err != nil {
+					ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+					return
+				}
+				ctx.SetStatusCode(fasthttp.StatusOK)
+				return
+			}
+		default:
+			{
+				ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+				return
+			}
+		}
+	}
+}
+
+// denied answers 401 without a valid token and 403 when its holder has no role in the forum
+func denied(ctx *fasthttp.RequestCtx, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch err.Error() {
+	case "unauthorized":
+		writeMessage(ctx, fasthttp.StatusUnauthorized, "Token is missing or invalid")
+		return true
+	case "forbidden":
+		writeMessage(ctx, fasthttp.StatusForbidden, "Not allowed to manage the webhooks of this forum")
+		return true
+	}
+
+	return false
+}
+
+func writeMessage(ctx *fasthttp.RequestCtx, status int, description string) {
+	msg := message.Message{
+		Description: description,
+	}
+	if _, err := easyjson.MarshalToWriter(msg, ctx.Response.BodyWriter()); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetStatusCode(status)
+}
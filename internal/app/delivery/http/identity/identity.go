@@ -0,0 +1,19 @@
+package identity
+
+import (
+	"bytes"
+
+	"github.com/valyala/fasthttp"
+)
+
+var bearer = []byte("Bearer ")
+
+// Token returns the bearer token of the request, empty when it carries none
+func Token(ctx *fasthttp.RequestCtx) string {
+	header := ctx.Request.Header.Peek("Authorization")
+	if !bytes.HasPrefix(header, bearer) {
+		return ""
+	}
+
+	return string(bytes.TrimSpace(header[len(bearer):]))
+}
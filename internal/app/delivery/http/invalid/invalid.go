@@ -0,0 +1,24 @@
+package invalid
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/validation"
+
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+// Write answers 400 with the rejected fields when err is a validation error and reports whether it did
+func Write(ctx *fasthttp.RequestCtx, err error) bool {
+	invalid, ok := err.(*validation.Error)
+	if !ok {
+		return false
+	}
+
+	if _, err := easyjson.MarshalToWriter(invalid, ctx.Response.BodyWriter()); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		return true
+	}
+
+	ctx.SetStatusCode(fasthttp.StatusBadRequest)
+	return true
+}
@@ -0,0 +1,128 @@
+package pagination
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+)
+
+var envelopeProfile = []byte("profile=envelope")
+
+// Window holds the cursors around a single page
+type Window struct {
+	Next *cursor.Cursor
+	Prev *cursor.Cursor
+}
+
+// Cursor decodes the opaque ?cursor= token, nil means the first page
+func Cursor(ctx *fasthttp.RequestCtx) (*cursor.Cursor, error) {
+	if exists := ctx.QueryArgs().Has("cursor"); !exists {
+		return nil, nil
+	}
+
+	return cursor.Decode(string(ctx.QueryArgs().Peek("cursor")))
+}
+
+// Backward reports whether the page was requested towards the start of the list
+func Backward(after *cursor.Cursor) bool {
+	return after != nil && after.Backward
+}
+
+// Reverse restores list order of a page fetched backward
+func Reverse(items interface{}) {
+	swap := reflect.Swapper(items)
+	for i, j := 0, reflect.ValueOf(items).Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
+
+// NewWindow works out the neighbouring pages of a page of count rows, key builds the cursor of the i-th row.
+// Resumed pages, those that start after since or a cursor, get a cursor back to the start of the list.
+func NewWindow(after *cursor.Cursor, resumed, full bool, count int, key func(i int) cursor.Cursor) *Window {
+	window := &Window{}
+	if count == 0 {
+		return window
+	}
+
+	backward := Backward(after)
+	if full || backward {
+		last := key(count - 1)
+		window.Next = &last
+	}
+	if backward && full || !backward && resumed {
+		first := key(0)
+		first.Backward = true
+		window.Prev = &first
+	}
+
+	return window
+}
+
+// Link builds a Link header value for the given rel, keeping the other query arguments
+func Link(ctx *fasthttp.RequestCtx, token, rel string) string {
+	uri := &fasthttp.URI{}
+	ctx.URI().CopyTo(uri)
+
+	args := uri.QueryArgs()
+	args.Del("since")
+	args.Set("cursor", token)
+
+	return "<" + string(uri.RequestURI()) + `>; rel="` + rel + `"`
+}
+
+// Enveloped reports whether the client asked for list metadata
+// with ?envelope=true or an Accept header carrying profile=envelope
+func Enveloped(ctx *fasthttp.RequestCtx) bool {
+	if ctx.QueryArgs().GetBool("envelope") {
+		return true
+	}
+
+	accept := bytes.Replace(ctx.Request.Header.Peek("Accept"), []byte(`"`), nil, -1)
+	return bytes.Contains(accept, envelopeProfile)
+}
+
+// Write sends a page as a bare list or, when asked for, wrapped with its total and cursors.
+// The total is only counted for enveloped responses.
+func Write(ctx *fasthttp.RequestCtx, items easyjson.Marshaler, window *Window, count func() (*page.Total, error)) error {
+	var next, prev *string
+	if window.Next != nil {
+		token := window.Next.Encode()
+		ctx.Response.Header.Add("Link", Link(ctx, token, "next"))
+		next = &token
+	}
+	if window.Prev != nil {
+		token := window.Prev.Encode()
+		ctx.Response.Header.Add("Link", Link(ctx, token, "prev"))
+		prev = &token
+	}
+
+	if !Enveloped(ctx) {
+		_, err := easyjson.MarshalToWriter(items, ctx.Response.BodyWriter())
+		return err
+	}
+
+	total, err := count()
+	if err != nil {
+		return err
+	}
+
+	raw, err := easyjson.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	envelope := &page.Page{
+		Items:   raw,
+		Total:   total.Count,
+		AtLeast: total.AtLeast,
+		HasMore: next != nil,
+		Next:    next,
+		Prev:    prev,
+	}
+	_, err = easyjson.MarshalToWriter(envelope, ctx.Response.BodyWriter())
+	return err
+}
@@ -0,0 +1,35 @@
+package abuse
+
+//go:generate easyjson abuse.go
+
+import "time"
+
+const (
+	ActionAllow      = "allow"
+	ActionReject     = "reject"
+	ActionQuarantine = "quarantine"
+)
+
+const (
+	ReasonRateLimited = "rate_limited"
+	ReasonDuplicate   = "duplicate"
+	ReasonFiltered    = "filtered"
+)
+
+// Verdict is the decision of a content filter, Reason explains anything but ActionAllow
+type Verdict struct {
+	Action string
+	Reason string
+}
+
+//easyjson:json
+type Error struct {
+	Description string        `json:"message"`
+	Reason      string        `json:"reason"`
+	Detail      string        `json:"detail,omitempty"`
+	RetryAfter  time.Duration `json:"-"`
+}
+
+func (e *Error) Error() string {
+	return e.Description
+}
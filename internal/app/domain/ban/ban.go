@@ -0,0 +1,37 @@
+package ban
+
+//go:generate easyjson ban.go
+
+import "time"
+
+//easyjson:json
+type Ban struct {
+	ID           uint64     `json:"id"`
+	UserNickname string     `json:"nickname"`
+	ForumSlug    *string    `json:"forum,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+	Moderator    string     `json:"moderator"`
+	Expires      *time.Time `json:"expires,omitempty"`
+	Created      time.Time  `json:"created"`
+}
+
+//easyjson:json
+type Bans []Ban
+
+//easyjson:json
+type Create struct {
+	ForumSlug *string    `json:"forum"`
+	Reason    string     `json:"reason"`
+	Moderator string     `json:"moderator"`
+	Expires   *time.Time `json:"expires"`
+}
+
+//easyjson:json
+type Error struct {
+	Description string `json:"message"`
+	Ban         *Ban   `json:"ban"`
+}
+
+func (e *Error) Error() string {
+	return e.Description
+}
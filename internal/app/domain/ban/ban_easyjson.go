@@ -0,0 +1,478 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package ban
+
+import (
+	json "encoding/json"
+	time "time"
+
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan(in *jlexer.Lexer, out *Error) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			out.Description = string(in.String())
+		case "ban":
+			if in.IsNull() {
+				in.Skip()
+				out.Ban = nil
+			} else {
+				if out.Ban == nil {
+					out.Ban = new(Ban)
+				}
+				(*out.Ban).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan(out *jwriter.Writer, in Error) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"ban\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Ban == nil {
+			out.RawString("null")
+		} else {
+			(*in.Ban).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Error) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Error) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan(l, v)
+}
+func easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan1(in *jlexer.Lexer, out *Create) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forum":
+			if in.IsNull() {
+				in.Skip()
+				out.ForumSlug = nil
+			} else {
+				if out.ForumSlug == nil {
+					out.ForumSlug = new(string)
+				}
+				*out.ForumSlug = string(in.String())
+			}
+		case "reason":
+			out.Reason = string(in.String())
+		case "moderator":
+			out.Moderator = string(in.String())
+		case "expires":
+			if in.IsNull() {
+				in.Skip()
+				out.Expires = nil
+			} else {
+				if out.Expires == nil {
+					out.Expires = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Expires).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan1(out *jwriter.Writer, in Create) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.ForumSlug == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.ForumSlug))
+		}
+	}
+	{
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"moderator\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Moderator))
+	}
+	{
+		const prefix string = ",\"expires\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Expires == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.Expires).MarshalJSON())
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Create) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Create) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Create) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Create) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan1(l, v)
+}
+func easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan2(in *jlexer.Lexer, out *Bans) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Bans, 0, 1)
+			} else {
+				*out = Bans{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Ban
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan2(out *jwriter.Writer, in Bans) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Bans) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Bans) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Bans) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Bans) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan2(l, v)
+}
+func easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan3(in *jlexer.Lexer, out *Ban) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "nickname":
+			out.UserNickname = string(in.String())
+		case "forum":
+			if in.IsNull() {
+				in.Skip()
+				out.ForumSlug = nil
+			} else {
+				if out.ForumSlug == nil {
+					out.ForumSlug = new(string)
+				}
+				*out.ForumSlug = string(in.String())
+			}
+		case "reason":
+			out.Reason = string(in.String())
+		case "moderator":
+			out.Moderator = string(in.String())
+		case "expires":
+			if in.IsNull() {
+				in.Skip()
+				out.Expires = nil
+			} else {
+				if out.Expires == nil {
+					out.Expires = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Expires).UnmarshalJSON(data))
+				}
+			}
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan3(out *jwriter.Writer, in Ban) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserNickname))
+	}
+	if in.ForumSlug != nil {
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.ForumSlug))
+	}
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"moderator\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Moderator))
+	}
+	if in.Expires != nil {
+		const prefix string = ",\"expires\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.Expires).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Ban) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Ban) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2452dbc5EncodeGithubComZorinArsenijTechDbForumInternalAppDomainBan3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Ban) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Ban) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2452dbc5DecodeGithubComZorinArsenijTechDbForumInternalAppDomainBan3(l, v)
+}
@@ -0,0 +1,46 @@
+package bookmark
+
+//go:generate easyjson bookmark.go
+
+import "time"
+
+const (
+	TypePost   = "post"
+	TypeThread = "thread"
+)
+
+//easyjson:json
+type Bookmark struct {
+	ID        uint64  `json:"id"`
+	Type      string  `json:"type"`
+	PostID    *uint64 `json:"post,omitempty"`
+	ThreadID  uint64  `json:"thread"`
+	ForumSlug string  `json:"forum"`
+	// Title is the title of the thread, Message is set for post bookmarks only
+	Title   string    `json:"title"`
+	Message string    `json:"message,omitempty"`
+	Folder  string    `json:"folder,omitempty"`
+	Note    string    `json:"note,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+//easyjson:json
+type Bookmarks []Bookmark
+
+//easyjson:json
+type Create struct {
+	// Either a post or a thread, given by slug or id, is bookmarked
+	PostID *uint64 `json:"post,omitempty"`
+	Thread *string `json:"thread,omitempty"`
+	Folder string  `json:"folder,omitempty"`
+	Note   string  `json:"note,omitempty"`
+}
+
+//easyjson:json
+type Folder struct {
+	Name      string `json:"folder"`
+	Bookmarks int64  `json:"bookmarks"`
+}
+
+//easyjson:json
+type Folders []Folder
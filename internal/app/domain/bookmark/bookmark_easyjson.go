@@ -0,0 +1,547 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package bookmark
+
+import (
+	json "encoding/json"
+
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark(in *jlexer.Lexer, out *Folders) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Folders, 0, 2)
+			} else {
+				*out = Folders{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Folder
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark(out *jwriter.Writer, in Folders) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Folders) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Folders) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Folders) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Folders) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark(l, v)
+}
+func easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark1(in *jlexer.Lexer, out *Folder) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "folder":
+			out.Name = string(in.String())
+		case "bookmarks":
+			out.Bookmarks = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark1(out *jwriter.Writer, in Folder) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"folder\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"bookmarks\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Bookmarks))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Folder) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Folder) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Folder) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Folder) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark1(l, v)
+}
+func easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark2(in *jlexer.Lexer, out *Create) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "post":
+			if in.IsNull() {
+				in.Skip()
+				out.PostID = nil
+			} else {
+				if out.PostID == nil {
+					out.PostID = new(uint64)
+				}
+				*out.PostID = uint64(in.Uint64())
+			}
+		case "thread":
+			if in.IsNull() {
+				in.Skip()
+				out.Thread = nil
+			} else {
+				if out.Thread == nil {
+					out.Thread = new(string)
+				}
+				*out.Thread = string(in.String())
+			}
+		case "folder":
+			out.Folder = string(in.String())
+		case "note":
+			out.Note = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark2(out *jwriter.Writer, in Create) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.PostID != nil {
+		const prefix string = ",\"post\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(*in.PostID))
+	}
+	if in.Thread != nil {
+		const prefix string = ",\"thread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Thread))
+	}
+	if in.Folder != "" {
+		const prefix string = ",\"folder\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Folder))
+	}
+	if in.Note != "" {
+		const prefix string = ",\"note\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Note))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Create) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Create) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Create) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Create) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark2(l, v)
+}
+func easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark3(in *jlexer.Lexer, out *Bookmarks) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Bookmarks, 0, 1)
+			} else {
+				*out = Bookmarks{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 Bookmark
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark3(out *jwriter.Writer, in Bookmarks) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Bookmarks) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Bookmarks) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Bookmarks) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Bookmarks) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark3(l, v)
+}
+func easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark4(in *jlexer.Lexer, out *Bookmark) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "type":
+			out.Type = string(in.String())
+		case "post":
+			if in.IsNull() {
+				in.Skip()
+				out.PostID = nil
+			} else {
+				if out.PostID == nil {
+					out.PostID = new(uint64)
+				}
+				*out.PostID = uint64(in.Uint64())
+			}
+		case "thread":
+			out.ThreadID = uint64(in.Uint64())
+		case "forum":
+			out.ForumSlug = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "folder":
+			out.Folder = string(in.String())
+		case "note":
+			out.Note = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark4(out *jwriter.Writer, in Bookmark) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Type))
+	}
+	if in.PostID != nil {
+		const prefix string = ",\"post\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(*in.PostID))
+	}
+	{
+		const prefix string = ",\"thread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ThreadID))
+	}
+	{
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ForumSlug))
+	}
+	{
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	if in.Folder != "" {
+		const prefix string = ",\"folder\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Folder))
+	}
+	if in.Note != "" {
+		const prefix string = ",\"note\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Note))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Bookmark) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Bookmark) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Bookmark) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Bookmark) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComZorinArsenijTechDbForumInternalAppDomainBookmark4(l, v)
+}
@@ -0,0 +1,44 @@
+package conversation
+
+//go:generate easyjson conversation.go
+
+import "time"
+
+//easyjson:json
+type Conversation struct {
+	ID           uint64    `json:"id"`
+	Subject      string    `json:"subject,omitempty"`
+	Participants []string  `json:"participants"`
+	LastMessage  Message   `json:"last_message"`
+	Unread       int       `json:"unread"`
+	Created      time.Time `json:"created"`
+}
+
+//easyjson:json
+type Conversations []Conversation
+
+//easyjson:json
+type Message struct {
+	ID             uint64    `json:"id"`
+	ConversationID uint64    `json:"conversation"`
+	Author         string    `json:"author"`
+	Message        string    `json:"message"`
+	Created        time.Time `json:"created"`
+}
+
+//easyjson:json
+type Messages []Message
+
+//easyjson:json
+type Send struct {
+	// Without a conversation a new one is started with the recipients
+	ConversationID *uint64  `json:"conversation,omitempty"`
+	Recipients     []string `json:"recipients,omitempty"`
+	Subject        string   `json:"subject,omitempty"`
+	Message        string   `json:"message"`
+}
+
+//easyjson:json
+type Unread struct {
+	Count int64 `json:"unread"`
+}
@@ -0,0 +1,654 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package conversation
+
+import (
+	json "encoding/json"
+
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation(in *jlexer.Lexer, out *Unread) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "unread":
+			out.Count = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation(out *jwriter.Writer, in Unread) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"unread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Unread) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Unread) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Unread) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Unread) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation(l, v)
+}
+func easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation1(in *jlexer.Lexer, out *Send) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "conversation":
+			if in.IsNull() {
+				in.Skip()
+				out.ConversationID = nil
+			} else {
+				if out.ConversationID == nil {
+					out.ConversationID = new(uint64)
+				}
+				*out.ConversationID = uint64(in.Uint64())
+			}
+		case "recipients":
+			if in.IsNull() {
+				in.Skip()
+				out.Recipients = nil
+			} else {
+				in.Delim('[')
+				if out.Recipients == nil {
+					if !in.IsDelim(']') {
+						out.Recipients = make([]string, 0, 4)
+					} else {
+						out.Recipients = []string{}
+					}
+				} else {
+					out.Recipients = (out.Recipients)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Recipients = append(out.Recipients, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "subject":
+			out.Subject = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation1(out *jwriter.Writer, in Send) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ConversationID != nil {
+		const prefix string = ",\"conversation\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(*in.ConversationID))
+	}
+	if len(in.Recipients) != 0 {
+		const prefix string = ",\"recipients\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Recipients {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Subject != "" {
+		const prefix string = ",\"subject\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Subject))
+	}
+	{
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Send) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Send) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Send) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Send) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation1(l, v)
+}
+func easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation2(in *jlexer.Lexer, out *Messages) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Messages, 0, 1)
+			} else {
+				*out = Messages{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 Message
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation2(out *jwriter.Writer, in Messages) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Messages) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Messages) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Messages) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Messages) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation2(l, v)
+}
+func easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation3(in *jlexer.Lexer, out *Message) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "conversation":
+			out.ConversationID = uint64(in.Uint64())
+		case "author":
+			out.Author = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation3(out *jwriter.Writer, in Message) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"conversation\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ConversationID))
+	}
+	{
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Message) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Message) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Message) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation3(l, v)
+}
+func easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation4(in *jlexer.Lexer, out *Conversations) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Conversations, 0, 1)
+			} else {
+				*out = Conversations{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v7 Conversation
+			(v7).UnmarshalEasyJSON(in)
+			*out = append(*out, v7)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation4(out *jwriter.Writer, in Conversations) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v8, v9 := range in {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			(v9).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Conversations) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Conversations) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Conversations) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Conversations) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation4(l, v)
+}
+func easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation5(in *jlexer.Lexer, out *Conversation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "subject":
+			out.Subject = string(in.String())
+		case "participants":
+			if in.IsNull() {
+				in.Skip()
+				out.Participants = nil
+			} else {
+				in.Delim('[')
+				if out.Participants == nil {
+					if !in.IsDelim(']') {
+						out.Participants = make([]string, 0, 4)
+					} else {
+						out.Participants = []string{}
+					}
+				} else {
+					out.Participants = (out.Participants)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Participants = append(out.Participants, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "last_message":
+			(out.LastMessage).UnmarshalEasyJSON(in)
+		case "unread":
+			out.Unread = int(in.Int())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation5(out *jwriter.Writer, in Conversation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	if in.Subject != "" {
+		const prefix string = ",\"subject\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Subject))
+	}
+	{
+		const prefix string = ",\"participants\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Participants == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Participants {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"last_message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.LastMessage).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"unread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Unread))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Conversation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Conversation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Conversation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Conversation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeGithubComZorinArsenijTechDbForumInternalAppDomainConversation5(l, v)
+}
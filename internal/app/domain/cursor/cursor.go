@@ -0,0 +1,42 @@
+package cursor
+
+//go:generate easyjson cursor.go
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+var ErrInvalid = errors.New("invalidCursor")
+
+//easyjson:json
+type Cursor struct {
+	Key  string `json:"k"`
+	Type string `json:"t,omitempty"`
+	ID   uint64 `json:"i,omitempty"`
+
+	// Name breaks ties between rows sharing the key when they are ordered by name
+	Name string `json:"n,omitempty"`
+
+	// Backward cursors walk towards the start of the list
+	Backward bool `json:"b,omitempty"`
+}
+
+func (c *Cursor) Encode() string {
+	raw, _ := c.MarshalJSON()
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func Decode(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+
+	decoded := &Cursor{}
+	if err := decoded.UnmarshalJSON(raw); err != nil || decoded.Key == "" {
+		return nil, ErrInvalid
+	}
+
+	return decoded, nil
+}
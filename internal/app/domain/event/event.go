@@ -0,0 +1,245 @@
+package event
+
+//go:generate easyjson event.go
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+var ErrUnknownType = errors.New("unknownEventType")
+
+const (
+	TypeUserCreated   = "user.created"
+	TypeForumCreated  = "forum.created"
+	TypeThreadCreated = "thread.created"
+	TypeThreadVoted   = "thread.voted"
+	TypeThreadUpdated = "thread.updated"
+	TypePostCreated   = "post.created"
+	TypePostUpdated   = "post.updated"
+	TypePostVoted     = "post.voted"
+	TypePostReacted   = "post.reacted"
+	topicThreadPrefix = "thread:"
+	topicForumPrefix  = "forum:"
+)
+
+//easyjson:json
+type Event struct {
+	ID        uint64              `json:"id"`
+	Type      string              `json:"type"`
+	ThreadID  uint64              `json:"thread,omitempty"`
+	ForumSlug string              `json:"forum,omitempty"`
+	Data      easyjson.RawMessage `json:"data"`
+
+	// Subscribers that already handled the event and the failed delivery rounds so far
+	Handled  []string `json:"-"`
+	Attempts int      `json:"-"`
+}
+
+//easyjson:json
+type Events []Event
+
+func ThreadTopic(id uint64) string {
+	return topicThreadPrefix + strconv.FormatUint(id, 10)
+}
+
+// ForumTopic lowercases the slug since forum slugs are case insensitive
+func ForumTopic(slug string) string {
+	return topicForumPrefix + strings.ToLower(slug)
+}
+
+// Topics lists every topic an event is delivered to, user events belong to none
+func (e *Event) Topics() []string {
+	topics := make([]string, 0, 2)
+	if e.ThreadID != 0 {
+		topics = append(topics, ThreadTopic(e.ThreadID))
+	}
+	if e.ForumSlug != "" {
+		topics = append(topics, ForumTopic(e.ForumSlug))
+	}
+	return topics
+}
+
+// Typed is the body of an event as built by an interactor. Writes take the constructor of
+// their event, so the repository records it in the outbox within the same transaction.
+type Typed interface {
+	easyjson.Marshaler
+	EventType() string
+	EventThread() uint64
+	EventForum() string
+}
+
+type UserCreated struct {
+	User *user.User
+}
+
+func NewUserCreated(created *user.User) Typed {
+	return UserCreated{User: created}
+}
+
+func (e UserCreated) EventType() string                 { return TypeUserCreated }
+func (e UserCreated) EventThread() uint64               { return 0 }
+func (e UserCreated) EventForum() string                { return "" }
+func (e UserCreated) MarshalEasyJSON(w *jwriter.Writer) { e.User.MarshalEasyJSON(w) }
+
+type ForumCreated struct {
+	Forum *forum.Forum
+}
+
+func NewForumCreated(created *forum.Forum) Typed {
+	return ForumCreated{Forum: created}
+}
+
+func (e ForumCreated) EventType() string                 { return TypeForumCreated }
+func (e ForumCreated) EventThread() uint64               { return 0 }
+func (e ForumCreated) EventForum() string                { return e.Forum.Slug }
+func (e ForumCreated) MarshalEasyJSON(w *jwriter.Writer) { e.Forum.MarshalEasyJSON(w) }
+
+type ThreadCreated struct {
+	Thread *thread.Thread
+}
+
+func NewThreadCreated(created *thread.Thread) Typed {
+	return ThreadCreated{Thread: created}
+}
+
+func (e ThreadCreated) EventType() string                 { return TypeThreadCreated }
+func (e ThreadCreated) EventThread() uint64               { return e.Thread.ID }
+func (e ThreadCreated) EventForum() string                { return e.Thread.ForumSlug }
+func (e ThreadCreated) MarshalEasyJSON(w *jwriter.Writer) { e.Thread.MarshalEasyJSON(w) }
+
+// ThreadVoted carries the thread with its rating after the vote
+type ThreadVoted struct {
+	Thread *thread.Thread
+}
+
+func NewThreadVoted(voted *thread.Thread) Typed {
+	return ThreadVoted{Thread: voted}
+}
+
+func (e ThreadVoted) EventType() string                 { return TypeThreadVoted }
+func (e ThreadVoted) EventThread() uint64               { return e.Thread.ID }
+func (e ThreadVoted) EventForum() string                { return e.Thread.ForumSlug }
+func (e ThreadVoted) MarshalEasyJSON(w *jwriter.Writer) { e.Thread.MarshalEasyJSON(w) }
+
+// ThreadUpdated carries the thread after an edit of its title, message or tags
+type ThreadUpdated struct {
+	Thread *thread.Thread
+}
+
+func NewThreadUpdated(updated *thread.Thread) Typed {
+	return ThreadUpdated{Thread: updated}
+}
+
+func (e ThreadUpdated) EventType() string                 { return TypeThreadUpdated }
+func (e ThreadUpdated) EventThread() uint64               { return e.Thread.ID }
+func (e ThreadUpdated) EventForum() string                { return e.Thread.ForumSlug }
+func (e ThreadUpdated) MarshalEasyJSON(w *jwriter.Writer) { e.Thread.MarshalEasyJSON(w) }
+
+type PostCreated struct {
+	Post *post.Post
+}
+
+func NewPostCreated(created *post.Post) Typed {
+	return PostCreated{Post: created}
+}
+
+func (e PostCreated) EventType() string                 { return TypePostCreated }
+func (e PostCreated) EventThread() uint64               { return e.Post.ThreadID }
+func (e PostCreated) EventForum() string                { return e.Post.ForumSlug }
+func (e PostCreated) MarshalEasyJSON(w *jwriter.Writer) { e.Post.MarshalEasyJSON(w) }
+
+type PostUpdated struct {
+	Post *post.Post
+}
+
+func NewPostUpdated(updated *post.Post) Typed {
+	return PostUpdated{Post: updated}
+}
+
+func (e PostUpdated) EventType() string                 { return TypePostUpdated }
+func (e PostUpdated) EventThread() uint64               { return e.Post.ThreadID }
+func (e PostUpdated) EventForum() string                { return e.Post.ForumSlug }
+func (e PostUpdated) MarshalEasyJSON(w *jwriter.Writer) { e.Post.MarshalEasyJSON(w) }
+
+// PostVoted carries the post with its rating after the vote
+type PostVoted struct {
+	Post *post.Post
+}
+
+func NewPostVoted(voted *post.Post) Typed {
+	return PostVoted{Post: voted}
+}
+
+func (e PostVoted) EventType() string                 { return TypePostVoted }
+func (e PostVoted) EventThread() uint64               { return e.Post.ThreadID }
+func (e PostVoted) EventForum() string                { return e.Post.ForumSlug }
+func (e PostVoted) MarshalEasyJSON(w *jwriter.Writer) { e.Post.MarshalEasyJSON(w) }
+
+// PostReacted carries the post with its reaction counters after the change
+type PostReacted struct {
+	Post *post.Post
+}
+
+func NewPostReacted(reacted *post.Post) Typed {
+	return PostReacted{Post: reacted}
+}
+
+func (e PostReacted) EventType() string                 { return TypePostReacted }
+func (e PostReacted) EventThread() uint64               { return e.Post.ThreadID }
+func (e PostReacted) EventForum() string                { return e.Post.ForumSlug }
+func (e PostReacted) MarshalEasyJSON(w *jwriter.Writer) { e.Post.MarshalEasyJSON(w) }
+
+// Decode turns a dispatched event back into its typed body
+func (e *Event) Decode() (Typed, error) {
+	switch e.Type {
+	case TypeUserCreated:
+		decoded := &user.User{}
+		if err := decoded.UnmarshalJSON(e.Data); err != nil {
+			return nil, err
+		}
+		return UserCreated{User: decoded}, nil
+	case TypeForumCreated:
+		decoded := &forum.Forum{}
+		if err := decoded.UnmarshalJSON(e.Data); err != nil {
+			return nil, err
+		}
+		return ForumCreated{Forum: decoded}, nil
+	case TypeThreadCreated, TypeThreadVoted, TypeThreadUpdated:
+		decoded := &thread.Thread{}
+		if err := decoded.UnmarshalJSON(e.Data); err != nil {
+			return nil, err
+		}
+		switch e.Type {
+		case TypeThreadVoted:
+			return ThreadVoted{Thread: decoded}, nil
+		case TypeThreadUpdated:
+			return ThreadUpdated{Thread: decoded}, nil
+		}
+		return ThreadCreated{Thread: decoded}, nil
+	case TypePostCreated, TypePostUpdated, TypePostVoted, TypePostReacted:
+		decoded := &post.Post{}
+		if err := decoded.UnmarshalJSON(e.Data); err != nil {
+			return nil, err
+		}
+		switch e.Type {
+		case TypePostUpdated:
+			return PostUpdated{Post: decoded}, nil
+		case TypePostVoted:
+			return PostVoted{Post: decoded}, nil
+		case TypePostReacted:
+			return PostReacted{Post: decoded}, nil
+		}
+		return PostCreated{Post: decoded}, nil
+	}
+
+	return nil, ErrUnknownType
+}
@@ -2,20 +2,49 @@ package forum
 
 //go:generate easyjson forum.go
 
+const (
+	SortByTitle   = "title"
+	SortByCreated = "created"
+	SortByThreads = "threads"
+	SortByPosts   = "posts"
+)
+
 //easyjson:json
 type Forum struct {
-	ID           uint64 `json:"-"`
-	Slug         string `json:"slug"`
-	Title        string `json:"title"`
-	Threads      int    `json:"threads"`
-	Posts        int64  `json:"posts"`
-	UserID       uint64 `json:"-"`
-	UserNickname string `json:"user"`
+	// Threads and posts count the forum itself, only the tree routes add up its subforums
+	ID           uint64  `json:"-"`
+	Slug         string  `json:"slug"`
+	Title        string  `json:"title"`
+	Threads      int     `json:"threads"`
+	Posts        int64   `json:"posts"`
+	UserID       uint64  `json:"-"`
+	UserNickname string  `json:"user"`
+	Parent       *string `json:"parent,omitempty"`
+	Category     bool    `json:"category,omitempty"`
+}
+
+//easyjson:json
+type Forums []Forum
+
+//easyjson:json
+type Node struct {
+	Forum
+	// Totals include the threads and posts of every subforum below the node, even those past the depth
+	TotalThreads int   `json:"total_threads"`
+	TotalPosts   int64 `json:"total_posts"`
+	Children     Tree  `json:"children"`
+	// More marks a node whose subforums lie below the requested depth
+	More bool `json:"more,omitempty"`
 }
 
+//easyjson:json
+type Tree []Node
+
 //easyjson:json
 type Create struct {
-	Slug         string `json:"slug"`
-	Title        string `json:"title"`
-	UserNickname string `json:"user"`
+	Slug         string  `json:"slug"`
+	Title        string  `json:"title"`
+	UserNickname string  `json:"user"`
+	Parent       *string `json:"parent,omitempty"`
+	Category     bool    `json:"category,omitempty"`
 }
@@ -18,7 +18,338 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(in *jlexer.Lexer, out *Forum) {
+func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(in *jlexer.Lexer, out *Tree) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Tree, 0, 1)
+			} else {
+				*out = Tree{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Node
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(out *jwriter.Writer, in Tree) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Tree) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Tree) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Tree) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Tree) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(l, v)
+}
+func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(in *jlexer.Lexer, out *Node) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total_threads":
+			out.TotalThreads = int(in.Int())
+		case "total_posts":
+			out.TotalPosts = int64(in.Int64())
+		case "children":
+			(out.Children).UnmarshalEasyJSON(in)
+		case "more":
+			out.More = bool(in.Bool())
+		case "slug":
+			out.Slug = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "threads":
+			out.Threads = int(in.Int())
+		case "posts":
+			out.Posts = int64(in.Int64())
+		case "user":
+			out.UserNickname = string(in.String())
+		case "parent":
+			if in.IsNull() {
+				in.Skip()
+				out.Parent = nil
+			} else {
+				if out.Parent == nil {
+					out.Parent = new(string)
+				}
+				*out.Parent = string(in.String())
+			}
+		case "category":
+			out.Category = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(out *jwriter.Writer, in Node) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"total_threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.TotalThreads))
+	}
+	{
+		const prefix string = ",\"total_posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.TotalPosts))
+	}
+	{
+		const prefix string = ",\"children\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Children).MarshalEasyJSON(out)
+	}
+	if in.More {
+		const prefix string = ",\"more\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.More))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Threads))
+	}
+	{
+		const prefix string = ",\"posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Posts))
+	}
+	{
+		const prefix string = ",\"user\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserNickname))
+	}
+	if in.Parent != nil {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Parent))
+	}
+	if in.Category {
+		const prefix string = ",\"category\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Category))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Node) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Node) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Node) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Node) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(l, v)
+}
+func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum2(in *jlexer.Lexer, out *Forums) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Forums, 0, 1)
+			} else {
+				*out = Forums{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 Forum
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum2(out *jwriter.Writer, in Forums) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Forums) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Forums) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Forums) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Forums) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum2(l, v)
+}
+func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum3(in *jlexer.Lexer, out *Forum) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -47,6 +378,18 @@ func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForu
 			out.Posts = int64(in.Int64())
 		case "user":
 			out.UserNickname = string(in.String())
+		case "parent":
+			if in.IsNull() {
+				in.Skip()
+				out.Parent = nil
+			} else {
+				if out.Parent == nil {
+					out.Parent = new(string)
+				}
+				*out.Parent = string(in.String())
+			}
+		case "category":
+			out.Category = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -57,7 +400,7 @@ func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForu
 		in.Consumed()
 	}
 }
-func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(out *jwriter.Writer, in Forum) {
+func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum3(out *jwriter.Writer, in Forum) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -111,33 +454,53 @@ func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForu
 		}
 		out.String(string(in.UserNickname))
 	}
+	if in.Parent != nil {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Parent))
+	}
+	if in.Category {
+		const prefix string = ",\"category\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Category))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Forum) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(&w, v)
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Forum) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(w, v)
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Forum) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(&r, v)
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Forum) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum(l, v)
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum3(l, v)
 }
-func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(in *jlexer.Lexer, out *Create) {
+func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum4(in *jlexer.Lexer, out *Create) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -162,6 +525,18 @@ func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForu
 			out.Title = string(in.String())
 		case "user":
 			out.UserNickname = string(in.String())
+		case "parent":
+			if in.IsNull() {
+				in.Skip()
+				out.Parent = nil
+			} else {
+				if out.Parent == nil {
+					out.Parent = new(string)
+				}
+				*out.Parent = string(in.String())
+			}
+		case "category":
+			out.Category = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -172,7 +547,7 @@ func easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForu
 		in.Consumed()
 	}
 }
-func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(out *jwriter.Writer, in Create) {
+func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum4(out *jwriter.Writer, in Create) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -206,29 +581,49 @@ func easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForu
 		}
 		out.String(string(in.UserNickname))
 	}
+	if in.Parent != nil {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Parent))
+	}
+	if in.Category {
+		const prefix string = ",\"category\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Category))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Create) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(&w, v)
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Create) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(w, v)
+	easyjsonC8d74561EncodeGithubComZorinArsenijTechDbForumInternalAppDomainForum4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Create) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(&r, v)
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Create) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum1(l, v)
+	easyjsonC8d74561DecodeGithubComZorinArsenijTechDbForumInternalAppDomainForum4(l, v)
 }
@@ -0,0 +1,81 @@
+package moderation
+
+//go:generate easyjson moderation.go
+
+import "time"
+
+const (
+	KindReport     = "report"
+	KindQuarantine = "quarantine"
+)
+
+const (
+	ActionDismiss = "dismiss"
+	ActionHide    = "hide"
+	ActionDelete  = "delete"
+	ActionBan     = "ban"
+)
+
+const (
+	RoleOwner     = "owner"
+	RoleModerator = "moderator"
+)
+
+//easyjson:json
+type Report struct {
+	UserNickname string `json:"author"`
+	Reason       string `json:"reason"`
+}
+
+//easyjson:json
+type Item struct {
+	ID           uint64    `json:"id"`
+	Kind         string    `json:"kind"`
+	PostID       *uint64   `json:"post,omitempty"`
+	ThreadID     uint64    `json:"thread"`
+	ForumSlug    string    `json:"forum"`
+	UserNickname string    `json:"author"`
+	Message      string    `json:"message"`
+	Reasons      []string  `json:"reasons"`
+	Reports      int       `json:"reports"`
+	Created      time.Time `json:"created"`
+
+	// Target is the reported post or the held post_quarantine entry, only reports expose it as post
+	Target uint64 `json:"-"`
+}
+
+//easyjson:json
+type Items []Item
+
+//easyjson:json
+type Decision struct {
+	// Moderator is the holder of the request token, it isn't read from the body
+	Moderator string `json:"-"`
+	Action    string `json:"action"`
+	Note      string `json:"note,omitempty"`
+}
+
+//easyjson:json
+type Action struct {
+	ID           uint64    `json:"id"`
+	ItemID       uint64    `json:"item"`
+	ForumSlug    string    `json:"forum"`
+	Moderator    string    `json:"moderator"`
+	Action       string    `json:"action"`
+	Note         string    `json:"note,omitempty"`
+	UserNickname string    `json:"author"`
+	Created      time.Time `json:"created"`
+}
+
+//easyjson:json
+type Actions []Action
+
+//easyjson:json
+type Moderator struct {
+	Nickname string    `json:"nickname"`
+	AddedBy  string    `json:"added_by"`
+	Created  time.Time `json:"created"`
+}
+
+//easyjson:json
+type Moderators []Moderator
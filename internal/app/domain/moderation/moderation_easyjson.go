@@ -0,0 +1,859 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package moderation
+
+import (
+	json "encoding/json"
+
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration(in *jlexer.Lexer, out *Report) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "author":
+			out.UserNickname = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration(out *jwriter.Writer, in Report) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserNickname))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Report) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Report) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Report) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Report) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration(l, v)
+}
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration1(in *jlexer.Lexer, out *Moderators) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Moderators, 0, 1)
+			} else {
+				*out = Moderators{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Moderator
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration1(out *jwriter.Writer, in Moderators) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Moderators) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Moderators) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Moderators) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Moderators) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration1(l, v)
+}
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration2(in *jlexer.Lexer, out *Moderator) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "added_by":
+			out.AddedBy = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration2(out *jwriter.Writer, in Moderator) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	{
+		const prefix string = ",\"added_by\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.AddedBy))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Moderator) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Moderator) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Moderator) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Moderator) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration2(l, v)
+}
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration3(in *jlexer.Lexer, out *Items) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Items, 0, 1)
+			} else {
+				*out = Items{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 Item
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration3(out *jwriter.Writer, in Items) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Items) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Items) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Items) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Items) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration3(l, v)
+}
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration4(in *jlexer.Lexer, out *Item) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "kind":
+			out.Kind = string(in.String())
+		case "post":
+			if in.IsNull() {
+				in.Skip()
+				out.PostID = nil
+			} else {
+				if out.PostID == nil {
+					out.PostID = new(uint64)
+				}
+				*out.PostID = uint64(in.Uint64())
+			}
+		case "thread":
+			out.ThreadID = uint64(in.Uint64())
+		case "forum":
+			out.ForumSlug = string(in.String())
+		case "author":
+			out.UserNickname = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "reasons":
+			if in.IsNull() {
+				in.Skip()
+				out.Reasons = nil
+			} else {
+				in.Delim('[')
+				if out.Reasons == nil {
+					if !in.IsDelim(']') {
+						out.Reasons = make([]string, 0, 4)
+					} else {
+						out.Reasons = []string{}
+					}
+				} else {
+					out.Reasons = (out.Reasons)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Reasons = append(out.Reasons, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "reports":
+			out.Reports = int(in.Int())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration4(out *jwriter.Writer, in Item) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Kind))
+	}
+	if in.PostID != nil {
+		const prefix string = ",\"post\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(*in.PostID))
+	}
+	{
+		const prefix string = ",\"thread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ThreadID))
+	}
+	{
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ForumSlug))
+	}
+	{
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserNickname))
+	}
+	{
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"reasons\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Reasons == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Reasons {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"reports\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Reports))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Item) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Item) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Item) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Item) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration4(l, v)
+}
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration5(in *jlexer.Lexer, out *Decision) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "action":
+			out.Action = string(in.String())
+		case "note":
+			out.Note = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration5(out *jwriter.Writer, in Decision) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"action\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Action))
+	}
+	if in.Note != "" {
+		const prefix string = ",\"note\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Note))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Decision) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Decision) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Decision) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Decision) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration5(l, v)
+}
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration6(in *jlexer.Lexer, out *Actions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Actions, 0, 1)
+			} else {
+				*out = Actions{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v10 Action
+			(v10).UnmarshalEasyJSON(in)
+			*out = append(*out, v10)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration6(out *jwriter.Writer, in Actions) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v11, v12 := range in {
+			if v11 > 0 {
+				out.RawByte(',')
+			}
+			(v12).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Actions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Actions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Actions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Actions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration6(l, v)
+}
+func easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration7(in *jlexer.Lexer, out *Action) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "item":
+			out.ItemID = uint64(in.Uint64())
+		case "forum":
+			out.ForumSlug = string(in.String())
+		case "moderator":
+			out.Moderator = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "note":
+			out.Note = string(in.String())
+		case "author":
+			out.UserNickname = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration7(out *jwriter.Writer, in Action) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"item\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ItemID))
+	}
+	{
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ForumSlug))
+	}
+	{
+		const prefix string = ",\"moderator\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Moderator))
+	}
+	{
+		const prefix string = ",\"action\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Action))
+	}
+	if in.Note != "" {
+		const prefix string = ",\"note\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Note))
+	}
+	{
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserNickname))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Action) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Action) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE913b498EncodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Action) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Action) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE913b498DecodeGithubComZorinArsenijTechDbForumInternalAppDomainModeration7(l, v)
+}
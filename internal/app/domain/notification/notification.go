@@ -0,0 +1,48 @@
+package notification
+
+//go:generate easyjson notification.go
+
+import "time"
+
+const (
+	// KindPost is a new post in a followed thread
+	KindPost = "post"
+	// KindReply is a post answering one of the user's posts
+	KindReply = "reply"
+	// KindMention is a post referencing the user with an @mention
+	KindMention = "mention"
+	// KindThread is a new thread in a followed forum
+	KindThread = "thread"
+)
+
+//easyjson:json
+type Notification struct {
+	ID        uint64    `json:"id"`
+	Kind      string    `json:"kind"`
+	Author    string    `json:"author"`
+	ForumSlug string    `json:"forum"`
+	ThreadID  uint64    `json:"thread"`
+	PostID    *uint64   `json:"post,omitempty"`
+	Read      bool      `json:"read"`
+	Created   time.Time `json:"created"`
+}
+
+//easyjson:json
+type Notifications []Notification
+
+//easyjson:json
+type Subscription struct {
+	UserNickname string `json:"nickname"`
+	ThreadID     uint64 `json:"thread,omitempty"`
+	ForumSlug    string `json:"forum,omitempty"`
+}
+
+//easyjson:json
+type MarkRead struct {
+	IDs []uint64 `json:"ids"`
+}
+
+//easyjson:json
+type Unread struct {
+	Count int64 `json:"unread"`
+}
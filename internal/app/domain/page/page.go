@@ -0,0 +1,21 @@
+package page
+
+//go:generate easyjson page.go
+
+import "github.com/mailru/easyjson"
+
+//easyjson:json
+type Page struct {
+	Items   easyjson.RawMessage `json:"items"`
+	Total   int64               `json:"total"`
+	AtLeast bool                `json:"at_least,omitempty"`
+	HasMore bool                `json:"has_more"`
+	Next    *string             `json:"next,omitempty"`
+	Prev    *string             `json:"prev,omitempty"`
+}
+
+// Total is the size of a list, AtLeast marks a count that stopped at a cap and is only a lower bound
+type Total struct {
+	Count   int64
+	AtLeast bool
+}
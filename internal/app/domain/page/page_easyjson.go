@@ -0,0 +1,167 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package page
+
+import (
+	json "encoding/json"
+
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson7d177735DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPage(in *jlexer.Lexer, out *Page) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			(out.Items).UnmarshalEasyJSON(in)
+		case "total":
+			out.Total = int64(in.Int64())
+		case "at_least":
+			out.AtLeast = bool(in.Bool())
+		case "has_more":
+			out.HasMore = bool(in.Bool())
+		case "next":
+			if in.IsNull() {
+				in.Skip()
+				out.Next = nil
+			} else {
+				if out.Next == nil {
+					out.Next = new(string)
+				}
+				*out.Next = string(in.String())
+			}
+		case "prev":
+			if in.IsNull() {
+				in.Skip()
+				out.Prev = nil
+			} else {
+				if out.Prev == nil {
+					out.Prev = new(string)
+				}
+				*out.Prev = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson7d177735EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPage(out *jwriter.Writer, in Page) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Items).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"total\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Total))
+	}
+	if in.AtLeast {
+		const prefix string = ",\"at_least\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.AtLeast))
+	}
+	{
+		const prefix string = ",\"has_more\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.HasMore))
+	}
+	if in.Next != nil {
+		const prefix string = ",\"next\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Next))
+	}
+	if in.Prev != nil {
+		const prefix string = ",\"prev\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Prev))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Page) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson7d177735EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPage(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Page) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson7d177735EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPage(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Page) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson7d177735DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPage(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Page) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson7d177735DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPage(l, v)
+}
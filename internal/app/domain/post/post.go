@@ -25,6 +25,13 @@ type Post struct {
 	ForumSlug string `json:"forum"`
 
 	Parent int32 `json:"parent"`
+
+	Votes     int            `json:"votes"`
+	Reactions map[string]int `json:"reactions,omitempty"`
+	Mentions  []string       `json:"mentions,omitempty"`
+
+	MessageHTML string `json:"message_html,omitempty"`
+	Rendered    string `json:"-"`
 }
 
 //easyjson:json
@@ -32,11 +39,14 @@ type Posts []Post
 
 //easyjson:json
 type Create struct {
-	Message      string  `json:"message"`
-	UserNickname string  `json:"author"`
-	Parent       int32   `json:"parent"`
-	Parents      []int32 `json:"-"`
-	Root         int     `json:"-"`
+	Message      string   `json:"message"`
+	UserNickname string   `json:"author"`
+	Parent       int32    `json:"parent"`
+	Parents      []int32  `json:"-"`
+	Root         int      `json:"-"`
+	Mentions     []string `json:"-"`
+	Rendered     string   `json:"-"`
+	Quarantine   string   `json:"-"`
 }
 
 //easyjson:json
@@ -52,6 +62,36 @@ type Info struct {
 
 //easyjson:json
 type Update struct {
-	ID      string  `json:"-"`
-	Message *string `json:"message"`
+	ID       string   `json:"-"`
+	Message  *string  `json:"message"`
+	Mentions []string `json:"-"`
+	Rendered *string  `json:"-"`
+
+	// Editor is the holder of the request token, edits without one are attributed to the author
+	Editor *string `json:"-"`
+}
+
+//easyjson:json
+type Revision struct {
+	ID      uint64    `json:"id"`
+	PostID  uint64    `json:"post"`
+	Message string    `json:"message"`
+	Editor  string    `json:"editor"`
+	Created time.Time `json:"created"`
+}
+
+//easyjson:json
+type Revisions []Revision
+
+//easyjson:json
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+//easyjson:json
+type Diff struct {
+	From  uint64     `json:"from"`
+	To    *uint64    `json:"to,omitempty"`
+	Lines []DiffLine `json:"lines"`
 }
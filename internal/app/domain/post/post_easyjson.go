@@ -4,6 +4,7 @@ package post
 
 import (
 	json "encoding/json"
+
 	forum "github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
 	thread "github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 	user "github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
@@ -103,7 +104,7 @@ func (v *Update) UnmarshalJSON(data []byte) error {
 func (v *Update) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost(l, v)
 }
-func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(in *jlexer.Lexer, out *PostsCreate) {
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(in *jlexer.Lexer, out *Revisions) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -112,15 +113,15 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(PostsCreate, 0, 1)
+				*out = make(Revisions, 0, 1)
 			} else {
-				*out = PostsCreate{}
+				*out = Revisions{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v1 Create
+			var v1 Revision
 			(v1).UnmarshalEasyJSON(in)
 			*out = append(*out, v1)
 			in.WantComma()
@@ -131,7 +132,7 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(out *jwriter.Writer, in PostsCreate) {
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(out *jwriter.Writer, in Revisions) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -147,29 +148,150 @@ func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PostsCreate) MarshalJSON() ([]byte, error) {
+func (v Revisions) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PostsCreate) MarshalEasyJSON(w *jwriter.Writer) {
+func (v Revisions) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PostsCreate) UnmarshalJSON(data []byte) error {
+func (v *Revisions) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PostsCreate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *Revisions) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost1(l, v)
 }
-func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(in *jlexer.Lexer, out *Posts) {
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(in *jlexer.Lexer, out *Revision) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "post":
+			out.PostID = uint64(in.Uint64())
+		case "message":
+			out.Message = string(in.String())
+		case "editor":
+			out.Editor = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(out *jwriter.Writer, in Revision) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"post\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.PostID))
+	}
+	{
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"editor\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Editor))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Revision) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Revision) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Revision) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Revision) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(l, v)
+}
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(in *jlexer.Lexer, out *PostsCreate) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -178,15 +300,15 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(Posts, 0, 1)
+				*out = make(PostsCreate, 0, 1)
 			} else {
-				*out = Posts{}
+				*out = PostsCreate{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v4 Post
+			var v4 Create
 			(v4).UnmarshalEasyJSON(in)
 			*out = append(*out, v4)
 			in.WantComma()
@@ -197,7 +319,7 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(out *jwriter.Writer, in Posts) {
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(out *jwriter.Writer, in PostsCreate) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -212,30 +334,96 @@ func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 	}
 }
 
+// MarshalJSON supports json.Marshaler interface
+func (v PostsCreate) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostsCreate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostsCreate) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostsCreate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(l, v)
+}
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(in *jlexer.Lexer, out *Posts) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Posts, 0, 1)
+			} else {
+				*out = Posts{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v7 Post
+			(v7).UnmarshalEasyJSON(in)
+			*out = append(*out, v7)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(out *jwriter.Writer, in Posts) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v8, v9 := range in {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			(v9).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
 // MarshalJSON supports json.Marshaler interface
 func (v Posts) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(&w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Posts) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Posts) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(&r, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Posts) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost2(l, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(l, v)
 }
-func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(in *jlexer.Lexer, out *Post) {
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(in *jlexer.Lexer, out *Post) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -272,6 +460,53 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 			out.ForumSlug = string(in.String())
 		case "parent":
 			out.Parent = int32(in.Int32())
+		case "votes":
+			out.Votes = int(in.Int())
+		case "reactions":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Reactions = make(map[string]int)
+				} else {
+					out.Reactions = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v10 int
+					v10 = int(in.Int())
+					(out.Reactions)[key] = v10
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "mentions":
+			if in.IsNull() {
+				in.Skip()
+				out.Mentions = nil
+			} else {
+				in.Delim('[')
+				if out.Mentions == nil {
+					if !in.IsDelim(']') {
+						out.Mentions = make([]string, 0, 4)
+					} else {
+						out.Mentions = []string{}
+					}
+				} else {
+					out.Mentions = (out.Mentions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 string
+					v11 = string(in.String())
+					out.Mentions = append(out.Mentions, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "message_html":
+			out.MessageHTML = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -282,7 +517,7 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(out *jwriter.Writer, in Post) {
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(out *jwriter.Writer, in Post) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -366,33 +601,96 @@ func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		}
 		out.Int32(int32(in.Parent))
 	}
+	{
+		const prefix string = ",\"votes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Votes))
+	}
+	if len(in.Reactions) != 0 {
+		const prefix string = ",\"reactions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v12First := true
+			for v12Name, v12Value := range in.Reactions {
+				if v12First {
+					v12First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v12Name))
+				out.RawByte(':')
+				out.Int(int(v12Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Mentions) != 0 {
+		const prefix string = ",\"mentions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v13, v14 := range in.Mentions {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v14))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.MessageHTML != "" {
+		const prefix string = ",\"message_html\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MessageHTML))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Post) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(&w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Post) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Post) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(&r, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Post) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost3(l, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(l, v)
 }
-func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(in *jlexer.Lexer, out *Info) {
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost6(in *jlexer.Lexer, out *Info) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -453,7 +751,7 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(out *jwriter.Writer, in Info) {
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost6(out *jwriter.Writer, in Info) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -515,27 +813,245 @@ func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 // MarshalJSON supports json.Marshaler interface
 func (v Info) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(&w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Info) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Info) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(&r, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Info) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost4(l, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost6(l, v)
 }
-func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(in *jlexer.Lexer, out *Create) {
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost7(in *jlexer.Lexer, out *DiffLine) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "op":
+			out.Op = string(in.String())
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost7(out *jwriter.Writer, in DiffLine) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"op\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Op))
+	}
+	{
+		const prefix string = ",\"text\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DiffLine) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DiffLine) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DiffLine) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DiffLine) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost7(l, v)
+}
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost8(in *jlexer.Lexer, out *Diff) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from":
+			out.From = uint64(in.Uint64())
+		case "to":
+			if in.IsNull() {
+				in.Skip()
+				out.To = nil
+			} else {
+				if out.To == nil {
+					out.To = new(uint64)
+				}
+				*out.To = uint64(in.Uint64())
+			}
+		case "lines":
+			if in.IsNull() {
+				in.Skip()
+				out.Lines = nil
+			} else {
+				in.Delim('[')
+				if out.Lines == nil {
+					if !in.IsDelim(']') {
+						out.Lines = make([]DiffLine, 0, 2)
+					} else {
+						out.Lines = []DiffLine{}
+					}
+				} else {
+					out.Lines = (out.Lines)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v15 DiffLine
+					(v15).UnmarshalEasyJSON(in)
+					out.Lines = append(out.Lines, v15)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost8(out *jwriter.Writer, in Diff) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.From))
+	}
+	if in.To != nil {
+		const prefix string = ",\"to\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(*in.To))
+	}
+	{
+		const prefix string = ",\"lines\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Lines == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.Lines {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				(v17).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Diff) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Diff) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Diff) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Diff) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost8(l, v)
+}
+func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost9(in *jlexer.Lexer, out *Create) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -570,7 +1086,7 @@ func easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(out *jwriter.Writer, in Create) {
+func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost9(out *jwriter.Writer, in Create) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -610,23 +1126,23 @@ func easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost
 // MarshalJSON supports json.Marshaler interface
 func (v Create) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(&w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Create) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(w, v)
+	easyjson5a72dc82EncodeGithubComZorinArsenijTechDbForumInternalAppDomainPost9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Create) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(&r, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Create) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost5(l, v)
+	easyjson5a72dc82DecodeGithubComZorinArsenijTechDbForumInternalAppDomainPost9(l, v)
 }
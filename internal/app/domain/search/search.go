@@ -0,0 +1,44 @@
+package search
+
+//go:generate easyjson search.go
+
+import (
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+)
+
+const (
+	TypePost   = "post"
+	TypeThread = "thread"
+)
+
+//easyjson:json
+type Result struct {
+	Type         string    `json:"type"`
+	ID           uint64    `json:"id"`
+	ThreadID     uint64    `json:"thread"`
+	ForumSlug    string    `json:"forum"`
+	UserNickname string    `json:"author"`
+	Created      time.Time `json:"created"`
+	Title        string    `json:"title,omitempty"`
+	Snippet      string    `json:"snippet"`
+	Rank         float32   `json:"rank"`
+}
+
+//easyjson:json
+type Results struct {
+	Results []Result `json:"results"`
+	Cursor  *string  `json:"cursor,omitempty"`
+}
+
+type Query struct {
+	Text   string
+	Forum  *string
+	Thread *string
+	Author *string
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	After  *cursor.Cursor
+}
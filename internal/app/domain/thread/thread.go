@@ -9,6 +9,7 @@ type Thread struct {
 	ID    uint64 `json:"id"`
 	Votes int    `json:"votes"`
 	Create
+	MessageHTML string `json:"message_html,omitempty"`
 }
 
 //easyjson:json
@@ -22,10 +23,40 @@ type Create struct {
 	Created      *time.Time `json:"created,omitempty"`
 	UserNickname string     `json:"author"`
 	ForumSlug    string     `json:"forum"`
+	Tags         []string   `json:"tags,omitempty"`
+	Rendered     string     `json:"-"`
 }
 
 //easyjson:json
 type Update struct {
 	Message *string `json:"message"`
 	Title   *string `json:"title"`
+	// Tags replace the current ones when present, an empty list removes them all
+	Tags     *[]string `json:"tags"`
+	Rendered *string   `json:"-"`
+
+	// Editor is the holder of the request token, edits without one are attributed to the author
+	Editor *string `json:"-"`
+}
+
+//easyjson:json
+type Revision struct {
+	ID       uint64    `json:"id"`
+	ThreadID uint64    `json:"thread"`
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	Editor   string    `json:"editor"`
+	Created  time.Time `json:"created"`
 }
+
+//easyjson:json
+type Revisions []Revision
+
+//easyjson:json
+type Tag struct {
+	Name    string `json:"tag"`
+	Threads int64  `json:"threads"`
+}
+
+//easyjson:json
+type Tags []Tag
@@ -58,6 +58,37 @@ func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 				}
 				*out.Title = string(in.String())
 			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				if out.Tags == nil {
+					out.Tags = new([]string)
+				}
+				if in.IsNull() {
+					in.Skip()
+					*out.Tags = nil
+				} else {
+					in.Delim('[')
+					if *out.Tags == nil {
+						if !in.IsDelim(']') {
+							*out.Tags = make([]string, 0, 4)
+						} else {
+							*out.Tags = []string{}
+						}
+					} else {
+						*out.Tags = (*out.Tags)[:0]
+					}
+					for !in.IsDelim(']') {
+						var v1 string
+						v1 = string(in.String())
+						*out.Tags = append(*out.Tags, v1)
+						in.WantComma()
+					}
+					in.Delim(']')
+				}
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -100,6 +131,31 @@ func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 			out.String(string(*in.Title))
 		}
 	}
+	{
+		const prefix string = ",\"tags\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Tags == nil {
+			out.RawString("null")
+		} else {
+			if *in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+				out.RawString("null")
+			} else {
+				out.RawByte('[')
+				for v2, v3 := range *in.Tags {
+					if v2 > 0 {
+						out.RawByte(',')
+					}
+					out.String(string(v3))
+				}
+				out.RawByte(']')
+			}
+		}
+	}
 	out.RawByte('}')
 }
 
@@ -143,9 +199,9 @@ func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v1 Thread
-			(v1).UnmarshalEasyJSON(in)
-			*out = append(*out, v1)
+			var v4 Thread
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -159,11 +215,11 @@ func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v2, v3 := range in {
-			if v2 > 0 {
+		for v5, v6 := range in {
+			if v5 > 0 {
 				out.RawByte(',')
 			}
-			(v3).MarshalEasyJSON(out)
+			(v6).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -215,6 +271,8 @@ func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 			out.ID = uint64(in.Uint64())
 		case "votes":
 			out.Votes = int(in.Int())
+		case "message_html":
+			out.MessageHTML = string(in.String())
 		case "title":
 			out.Title = string(in.String())
 		case "slug":
@@ -245,6 +303,29 @@ func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 			out.UserNickname = string(in.String())
 		case "forum":
 			out.ForumSlug = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Tags = append(out.Tags, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -279,6 +360,16 @@ func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 		}
 		out.Int(int(in.Votes))
 	}
+	if in.MessageHTML != "" {
+		const prefix string = ",\"message_html\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MessageHTML))
+	}
 	{
 		const prefix string = ",\"title\":"
 		if first {
@@ -339,6 +430,25 @@ func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 		}
 		out.String(string(in.ForumSlug))
 	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Tags {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
@@ -365,7 +475,355 @@ func (v *Thread) UnmarshalJSON(data []byte) error {
 func (v *Thread) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread2(l, v)
 }
-func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(in *jlexer.Lexer, out *Create) {
+func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(in *jlexer.Lexer, out *Tags) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Tags, 0, 2)
+			} else {
+				*out = Tags{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v10 Tag
+			(v10).UnmarshalEasyJSON(in)
+			*out = append(*out, v10)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(out *jwriter.Writer, in Tags) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v11, v12 := range in {
+			if v11 > 0 {
+				out.RawByte(',')
+			}
+			(v12).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Tags) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Tags) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Tags) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Tags) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(l, v)
+}
+func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread4(in *jlexer.Lexer, out *Tag) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "tag":
+			out.Name = string(in.String())
+		case "threads":
+			out.Threads = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread4(out *jwriter.Writer, in Tag) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"tag\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Threads))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Tag) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Tag) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Tag) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Tag) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread4(l, v)
+}
+func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread5(in *jlexer.Lexer, out *Revisions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Revisions, 0, 1)
+			} else {
+				*out = Revisions{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v13 Revision
+			(v13).UnmarshalEasyJSON(in)
+			*out = append(*out, v13)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread5(out *jwriter.Writer, in Revisions) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v14, v15 := range in {
+			if v14 > 0 {
+				out.RawByte(',')
+			}
+			(v15).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Revisions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Revisions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Revisions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Revisions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread5(l, v)
+}
+func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread6(in *jlexer.Lexer, out *Revision) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "thread":
+			out.ThreadID = uint64(in.Uint64())
+		case "title":
+			out.Title = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "editor":
+			out.Editor = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread6(out *jwriter.Writer, in Revision) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"thread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ThreadID))
+	}
+	{
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"editor\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Editor))
+	}
+	{
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Revision) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Revision) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Revision) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Revision) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread6(l, v)
+}
+func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread7(in *jlexer.Lexer, out *Create) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -414,6 +872,29 @@ func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 			out.UserNickname = string(in.String())
 		case "forum":
 			out.ForumSlug = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 string
+					v16 = string(in.String())
+					out.Tags = append(out.Tags, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -424,7 +905,7 @@ func easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 		in.Consumed()
 	}
 }
-func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(out *jwriter.Writer, in Create) {
+func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread7(out *jwriter.Writer, in Create) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -488,29 +969,48 @@ func easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThrea
 		}
 		out.String(string(in.ForumSlug))
 	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v17, v18 := range in.Tags {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v18))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Create) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(&w, v)
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Create) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(w, v)
+	easyjson2d00218EncodeGithubComZorinArsenijTechDbForumInternalAppDomainThread7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Create) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(&r, v)
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Create) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread3(l, v)
+	easyjson2d00218DecodeGithubComZorinArsenijTechDbForumInternalAppDomainThread7(l, v)
 }
@@ -2,12 +2,26 @@ package user
 
 //go:generate easyjson user.go
 
+import "github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+
+const (
+	SortByNickname = "nickname"
+	SortByJoined   = "joined"
+)
+
 //easyjson:json
 type User struct {
+	ID       uint64 `json:"-"`
 	Email    string `json:"email"`
 	Nickname string `json:"nickname"`
 	Fullname string `json:"fullname"`
 	About    string `json:"about"`
+
+	Reputation *int `json:"reputation,omitempty"`
+	Posts      *int `json:"posts,omitempty"`
+	Threads    *int `json:"threads,omitempty"`
+
+	Bans ban.Bans `json:"bans,omitempty"`
 }
 
 //easyjson:json
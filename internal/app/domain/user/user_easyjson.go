@@ -111,6 +111,38 @@ func easyjson9e1087fdDecodeGithubComZorinArsenijTechDbForumInternalAppDomainUser
 			out.Fullname = string(in.String())
 		case "about":
 			out.About = string(in.String())
+		case "reputation":
+			if in.IsNull() {
+				in.Skip()
+				out.Reputation = nil
+			} else {
+				if out.Reputation == nil {
+					out.Reputation = new(int)
+				}
+				*out.Reputation = int(in.Int())
+			}
+		case "posts":
+			if in.IsNull() {
+				in.Skip()
+				out.Posts = nil
+			} else {
+				if out.Posts == nil {
+					out.Posts = new(int)
+				}
+				*out.Posts = int(in.Int())
+			}
+		case "threads":
+			if in.IsNull() {
+				in.Skip()
+				out.Threads = nil
+			} else {
+				if out.Threads == nil {
+					out.Threads = new(int)
+				}
+				*out.Threads = int(in.Int())
+			}
+		case "bans":
+			(out.Bans).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -165,6 +197,46 @@ func easyjson9e1087fdEncodeGithubComZorinArsenijTechDbForumInternalAppDomainUser
 		}
 		out.String(string(in.About))
 	}
+	if in.Reputation != nil {
+		const prefix string = ",\"reputation\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.Reputation))
+	}
+	if in.Posts != nil {
+		const prefix string = ",\"posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.Posts))
+	}
+	if in.Threads != nil {
+		const prefix string = ",\"threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.Threads))
+	}
+	if len(in.Bans) != 0 {
+		const prefix string = ",\"bans\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Bans).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -0,0 +1,29 @@
+package validation
+
+//go:generate easyjson validation.go
+
+const (
+	ReasonRequired = "required"
+	ReasonTooLong  = "too_long"
+	ReasonFormat   = "invalid_format"
+	ReasonTooMany  = "too_many"
+	ReasonPast     = "in_the_past"
+	ReasonConflict = "conflicting"
+)
+
+//easyjson:json
+type Error struct {
+	Description string  `json:"message"`
+	Fields      []Field `json:"fields"`
+}
+
+// Field names the rejected payload field, Limit is set for length and count violations
+type Field struct {
+	Name   string `json:"field"`
+	Reason string `json:"reason"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Description
+}
@@ -7,4 +7,14 @@ type Vote struct {
 	Rating       int    `json:"voice"`
 	Voice        bool   `json:"-"`
 	UserNickname string `json:"nickname"`
+	ThreadID     uint64 `json:"thread,omitempty"`
+}
+
+//easyjson:json
+type Votes []Vote
+
+//easyjson:json
+type Reaction struct {
+	Reaction     string `json:"reaction"`
+	UserNickname string `json:"nickname"`
 }
@@ -18,7 +18,73 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(in *jlexer.Lexer, out *Vote) {
+func easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(in *jlexer.Lexer, out *Votes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Votes, 0, 1)
+			} else {
+				*out = Votes{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Vote
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(out *jwriter.Writer, in Votes) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Votes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Votes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Votes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Votes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(l, v)
+}
+func easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote1(in *jlexer.Lexer, out *Vote) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -41,6 +107,8 @@ func easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote
 			out.Rating = int(in.Int())
 		case "nickname":
 			out.UserNickname = string(in.String())
+		case "thread":
+			out.ThreadID = uint64(in.Uint64())
 		default:
 			in.SkipRecursive()
 		}
@@ -51,7 +119,7 @@ func easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote
 		in.Consumed()
 	}
 }
-func easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(out *jwriter.Writer, in Vote) {
+func easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote1(out *jwriter.Writer, in Vote) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -75,29 +143,122 @@ func easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote
 		}
 		out.String(string(in.UserNickname))
 	}
+	if in.ThreadID != 0 {
+		const prefix string = ",\"thread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ThreadID))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Vote) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(&w, v)
+	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Vote) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(w, v)
+	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Vote) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(&r, v)
+	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Vote) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote(l, v)
+	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote1(l, v)
+}
+func easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote2(in *jlexer.Lexer, out *Reaction) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reaction":
+			out.Reaction = string(in.String())
+		case "nickname":
+			out.UserNickname = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote2(out *jwriter.Writer, in Reaction) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reaction\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reaction))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.UserNickname))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Reaction) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Reaction) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE3ecfa40EncodeGithubComZorinArsenijTechDbForumInternalAppDomainVote2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Reaction) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Reaction) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE3ecfa40DecodeGithubComZorinArsenijTechDbForumInternalAppDomainVote2(l, v)
 }
@@ -0,0 +1,71 @@
+package webhook
+
+//go:generate easyjson webhook.go
+
+import "time"
+
+const (
+	StatePending   = "pending"
+	StateDelivered = "delivered"
+	StateFailed    = "failed"
+)
+
+//easyjson:json
+type Webhook struct {
+	ID        uint64    `json:"id"`
+	ForumSlug string    `json:"forum"`
+	URL       string    `json:"url"`
+	Secret    *string   `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	Failures  int       `json:"failures"`
+	Created   time.Time `json:"created"`
+}
+
+//easyjson:json
+type Webhooks []Webhook
+
+//easyjson:json
+type Create struct {
+	URL    string   `json:"url"`
+	Secret *string  `json:"secret"`
+	Events []string `json:"events"`
+}
+
+//easyjson:json
+type Update struct {
+	URL    *string  `json:"url"`
+	Secret *string  `json:"secret"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+//easyjson:json
+type Delivery struct {
+	ID          uint64     `json:"id"`
+	WebhookID   uint64     `json:"webhook"`
+	EventID     uint64     `json:"event"`
+	EventType   string     `json:"type"`
+	State       string     `json:"state"`
+	Attempts    int        `json:"attempts"`
+	Status      *int       `json:"status,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+	NextAttempt *time.Time `json:"next_attempt,omitempty"`
+	Created     time.Time  `json:"created"`
+	Updated     time.Time  `json:"updated"`
+}
+
+//easyjson:json
+type Deliveries []Delivery
+
+// Job is a due delivery together with the endpoint it goes to
+type Job struct {
+	DeliveryID uint64
+	WebhookID  uint64
+	EventID    uint64
+	EventType  string
+	Attempts   int
+	URL        string
+	Secret     string
+	Payload    []byte
+}
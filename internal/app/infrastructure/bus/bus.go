@@ -0,0 +1,83 @@
+package bus
+
+import (
+	"sync"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+)
+
+func NewBus(buffer int) *Bus {
+	return &Bus{
+		buffer:      buffer,
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// Bus fans events out to in-memory subscribers of a topic.
+// Publishing never blocks: a subscriber whose buffer is full is dropped and its channel closed.
+type Bus struct {
+	buffer      int
+	mu          sync.Mutex
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	topic  string
+	events chan *event.Event
+}
+
+func (b *Bus) Publish(e *event.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range e.Topics() {
+		for s := range b.subscribers[topic] {
+			select {
+			case s.events <- e:
+			default:
+				b.remove(s)
+			}
+		}
+	}
+}
+
+// Handle lets the bus subscribe to the outbox dispatcher
+func (b *Bus) Handle(e *event.Event) error {
+	b.Publish(e)
+	return nil
+}
+
+// Subscribe returns the event channel of a topic and a cancel function releasing it
+func (b *Bus) Subscribe(topic string) (<-chan *event.Event, func()) {
+	s := &subscriber{
+		topic:  topic,
+		events: make(chan *event.Event, b.buffer),
+	}
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[topic][s] = struct{}{}
+	b.mu.Unlock()
+
+	return s.events, func() {
+		b.mu.Lock()
+		b.remove(s)
+		b.mu.Unlock()
+	}
+}
+
+// remove must be called with mu held, removing an already dropped subscriber is a no-op
+func (b *Bus) remove(s *subscriber) {
+	topic := b.subscribers[s.topic]
+	if _, ok := topic[s]; !ok {
+		return
+	}
+
+	delete(topic, s)
+	if len(topic) == 0 {
+		delete(b.subscribers, s.topic)
+	}
+	close(s.events)
+}
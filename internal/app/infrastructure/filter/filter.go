@@ -0,0 +1,64 @@
+package filter
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/abuse"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)`)
+
+func NewWordList(words []string, action string) *WordList {
+	blocked := make(map[string]bool, len(words))
+	for _, word := range words {
+		blocked[strings.ToLower(word)] = true
+	}
+
+	return &WordList{
+		blocked: blocked,
+		action:  action,
+	}
+}
+
+// WordList matches whole words case-insensitively
+type WordList struct {
+	blocked map[string]bool
+	action  string
+}
+
+func (w *WordList) Check(message string) abuse.Verdict {
+	words := strings.FieldsFunc(message, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, word := range words {
+		if w.blocked[strings.ToLower(word)] {
+			return abuse.Verdict{Action: w.action, Reason: "contains a blocked word"}
+		}
+	}
+
+	return abuse.Verdict{Action: abuse.ActionAllow}
+}
+
+func NewLinkLimit(max int, action string) *LinkLimit {
+	return &LinkLimit{
+		max:    max,
+		action: action,
+	}
+}
+
+// LinkLimit counts http, https and www. links
+type LinkLimit struct {
+	max    int
+	action string
+}
+
+func (l *LinkLimit) Check(message string) abuse.Verdict {
+	if len(linkPattern.FindAllStringIndex(message, l.max+1)) > l.max {
+		return abuse.Verdict{Action: l.action, Reason: "contains too many links"}
+	}
+
+	return abuse.Verdict{Action: abuse.ActionAllow}
+}
@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidRate = errors.New("invalidRate")
+
+// sweepInterval is how often buckets refilled to their burst are forgotten
+const sweepInterval = time.Minute
+
+// Rate refills Limit tokens every Interval, a bucket holds at most Burst tokens.
+// A zero Limit disables limiting.
+type Rate struct {
+	Limit    int
+	Interval time.Duration
+	Burst    int
+}
+
+func (r Rate) Enabled() bool {
+	return r.Limit > 0
+}
+
+// ParseRate reads a rate written as limit/interval:burst, for example 100/1s:2000.
+// The burst defaults to the limit and a plain 0 is the disabled rate.
+func ParseRate(raw string) (Rate, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "0" {
+		return Rate{}, nil
+	}
+
+	var rate Rate
+	limitRaw := raw
+	if idx := strings.LastIndexByte(raw, ':'); idx != -1 {
+		burst, err := strconv.Atoi(raw[idx+1:])
+		if err != nil || burst < 0 {
+			return Rate{}, ErrInvalidRate
+		}
+		rate.Burst = burst
+		limitRaw = raw[:idx]
+	}
+
+	parts := strings.SplitN(limitRaw, "/", 2)
+	if len(parts) != 2 {
+		return Rate{}, ErrInvalidRate
+	}
+
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil || limit < 0 {
+		return Rate{}, ErrInvalidRate
+	}
+	interval, err := time.ParseDuration(parts[1])
+	if err != nil || interval <= 0 {
+		return Rate{}, ErrInvalidRate
+	}
+
+	rate.Limit = limit
+	rate.Interval = interval
+	if rate.Burst == 0 {
+		rate.Burst = limit
+	}
+
+	return rate, nil
+}
+
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{
+		rate:     rate,
+		perToken: float64(rate.Interval) / float64(rate.Limit),
+		buckets:  make(map[string]*bucket),
+		swept:    time.Now(),
+	}
+}
+
+// Limiter is a token bucket per key, keys are client addresses or user nicknames
+type Limiter struct {
+	rate     Rate
+	perToken float64
+	mu       sync.Mutex
+	buckets  map[string]*bucket
+	swept    time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Allow takes cost tokens from the bucket of key. When they are missing nothing is taken
+// and the returned duration tells how long until they are available.
+func (l *Limiter) Allow(key string, cost int) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.swept) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.rate.Burst), updated: now}
+		l.buckets[key] = b
+	} else {
+		b.tokens = math.Min(float64(l.rate.Burst), b.tokens+float64(now.Sub(b.updated))/l.perToken)
+		b.updated = now
+	}
+
+	if b.tokens >= float64(cost) {
+		b.tokens -= float64(cost)
+		return true, 0
+	}
+
+	return false, time.Duration((float64(cost) - b.tokens) * l.perToken)
+}
+
+// sweep must be called with mu held, a full bucket behaves exactly like a missing one
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if float64(now.Sub(b.updated)) >= (float64(l.rate.Burst)-b.tokens)*l.perToken {
+			delete(l.buckets, key)
+		}
+	}
+	l.swept = now
+}
@@ -0,0 +1,159 @@
+package postgresql
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+
+	"github.com/jackc/pgx"
+)
+
+const (
+	getActiveBans       = "getActiveBans"
+	getBan              = "getBan"
+	createBan           = "createBan"
+	deleteBan           = "deleteBan"
+	findActiveBan       = "findActiveBan"
+	findActiveThreadBan = "findActiveThreadBan"
+	findActivePostBan   = "findActivePostBan"
+)
+
+var banQueries = map[string]string{
+	getActiveBans: `SELECT id, user_nickname, forum_slug, reason, moderator, expires, created
+	FROM ban
+	WHERE user_nickname = $1 AND (expires IS NULL OR expires > NOW())
+	ORDER BY id;`,
+
+	getBan: `SELECT id, user_nickname, forum_slug, reason, moderator, expires, created
+	FROM ban
+	WHERE id = $1 AND user_nickname = $2;`,
+
+	// A forum ban for a missing forum inserts nothing instead of turning into a global one
+	createBan: `INSERT INTO ban (user_nickname, forum_slug, reason, moderator, expires)
+	SELECT nickname, (SELECT slug FROM forum WHERE slug = $2::TEXT::CITEXT), $3, $4, $5
+	FROM client
+	WHERE nickname = $1 AND ($2::TEXT IS NULL OR EXISTS(SELECT 1 FROM forum WHERE slug = $2::TEXT::CITEXT))
+	RETURNING id, user_nickname, forum_slug, reason, moderator, expires, created;`,
+
+	deleteBan: `DELETE FROM ban
+	WHERE id = $1
+	RETURNING id;`,
+
+	// Global bans sort first, so they are reported over forum ones
+	findActiveBan: `SELECT id, user_nickname, forum_slug, reason, moderator, expires, created
+	FROM ban
+	WHERE user_nickname = ANY($1::TEXT[]::CITEXT[]) AND (forum_slug IS NULL OR forum_slug = $2::TEXT::CITEXT)
+		AND (expires IS NULL OR expires > NOW())
+	ORDER BY forum_slug NULLS FIRST, id
+	LIMIT 1;`,
+
+	// The author of the thread is checked along with the given users when $3 is set
+	findActiveThreadBan: `SELECT b.id, b.user_nickname, b.forum_slug, b.reason, b.moderator, b.expires, b.created
+	FROM ban AS b, (
+		SELECT forum_slug, user_nickname
+		FROM thread
+		WHERE slug = $2::TEXT::CITEXT OR id::TEXT = $2::TEXT
+		LIMIT 1
+	) AS t
+	WHERE (b.user_nickname = ANY($1::TEXT[]::CITEXT[]) OR ($3::BOOLEAN AND b.user_nickname = t.user_nickname))
+		AND (b.forum_slug IS NULL OR b.forum_slug = t.forum_slug)
+		AND (b.expires IS NULL OR b.expires > NOW())
+	ORDER BY b.forum_slug NULLS FIRST, b.id
+	LIMIT 1;`,
+
+	findActivePostBan: `SELECT b.id, b.user_nickname, b.forum_slug, b.reason, b.moderator, b.expires, b.created
+	FROM ban AS b
+	JOIN post AS p ON (p.id = $2)
+	WHERE (b.user_nickname = ANY($1::TEXT[]::CITEXT[]) OR ($3::BOOLEAN AND b.user_nickname = p.user_nickname))
+		AND (b.forum_slug IS NULL OR b.forum_slug = p.forum_slug)
+		AND (b.expires IS NULL OR b.expires > NOW())
+	ORDER BY b.forum_slug NULLS FIRST, b.id
+	LIMIT 1;`,
+}
+
+func NewBanRepo(conn *pgx.ConnPool) *Ban {
+	return &Ban{
+		conn: conn,
+	}
+}
+
+type Ban struct {
+	conn *pgx.ConnPool
+}
+
+// GetBans returns the bans of a user that have not expired yet
+func (b *Ban) GetBans(nickname string) (*ban.Bans, error) {
+	if err := b.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	rows, err := b.conn.Query(getActiveBans, nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make(ban.Bans, 0)
+	for rows.Next() {
+		received, err := scanBan(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		bans = append(bans, *received)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &bans, nil
+}
+
+func (b *Ban) GetBan(nickname string, id uint64) (*ban.Ban, error) {
+	return scanBan(b.conn.QueryRow(getBan, id, nickname))
+}
+
+func (b *Ban) CreateBan(nickname string, data *ban.Create) (*ban.Ban, error) {
+	return scanBan(b.conn.QueryRow(createBan, nickname, data.ForumSlug, data.Reason, data.Moderator, data.Expires))
+}
+
+// banFromForum places a ban without expiry as part of another write, such as a moderator decision
+func banFromForum(tx *pgx.Tx, nickname, forumSlug, reason, moderator string) (*ban.Ban, error) {
+	return scanBan(tx.QueryRow(createBan, nickname, forumSlug, reason, moderator, nil))
+}
+
+func (b *Ban) DeleteBan(id uint64) error {
+	return b.conn.QueryRow(deleteBan, id).Scan(&id)
+}
+
+// FindActiveBan returns a ban keeping any of the users out of the forum, nil when there is none
+func (b *Ban) FindActiveBan(nicknames []string, forumSlug string) (*ban.Ban, error) {
+	return findBan(b.conn.QueryRow(findActiveBan, nicknames, forumSlug))
+}
+
+// FindActiveThreadBan is FindActiveBan for the forum of a thread, author adds the thread author to the users
+func (b *Ban) FindActiveThreadBan(nicknames []string, slugOrId string, author bool) (*ban.Ban, error) {
+	return findBan(b.conn.QueryRow(findActiveThreadBan, nicknames, slugOrId, author))
+}
+
+// FindActivePostBan is FindActiveThreadBan for a post
+func (b *Ban) FindActivePostBan(nicknames []string, id string, author bool) (*ban.Ban, error) {
+	return findBan(b.conn.QueryRow(findActivePostBan, nicknames, id, author))
+}
+
+func findBan(row rowScanner) (*ban.Ban, error) {
+	found, err := scanBan(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+
+	return found, err
+}
+
+func scanBan(row rowScanner) (*ban.Ban, error) {
+	var received ban.Ban
+	if err := row.Scan(&received.ID, &received.UserNickname, &received.ForumSlug, &received.Reason, &received.Moderator,
+		&received.Expires, &received.Created); err != nil {
+		return nil, err
+	}
+
+	return &received, nil
+}
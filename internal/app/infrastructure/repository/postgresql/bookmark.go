@@ -0,0 +1,260 @@
+package postgresql
+
+import (
+	"errors"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/bookmark"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+
+	"github.com/jackc/pgx"
+)
+
+const (
+	getBookmarkPost            = "getBookmarkPost"
+	getBookmarkThread          = "getBookmarkThread"
+	getBookmarkByTarget        = "getBookmarkByTarget"
+	createBookmark             = "createBookmark"
+	getBookmarksLimit          = "getBookmarksLimit"
+	getBookmarksLimitDesc      = "getBookmarksLimitDesc"
+	getBookmarksLimitSince     = "getBookmarksLimitSince"
+	getBookmarksLimitSinceDesc = "getBookmarksLimitSinceDesc"
+	countBookmarks             = "countBookmarks"
+	getBookmarkFolders         = "getBookmarkFolders"
+	deleteBookmark             = "deleteBookmark"
+	deletePostBookmarks        = "deletePostBookmarks"
+)
+
+var bookmarkQueries = map[string]string{
+	getBookmarkPost: `SELECT p.thread_id, p.forum_slug, t.title, p.message
+	FROM post AS p
+	JOIN thread AS t ON (t.id = p.thread_id)
+	WHERE p.id = $1;`,
+
+	getBookmarkThread: `SELECT id, forum_slug, title
+	FROM thread
+	WHERE slug = $1 OR id::TEXT = $1;`,
+
+	getBookmarkByTarget: `SELECT b.id, b.post_id, b.thread_id, b.forum_slug, t.title, COALESCE(p.message, ''), COALESCE(b.folder, ''), b.note, b.created
+	FROM bookmark AS b
+	JOIN thread AS t ON (t.id = b.thread_id)
+	LEFT JOIN post AS p ON (p.id = b.post_id)
+	WHERE b.user_nickname = $1 AND b.thread_id = $2 AND COALESCE(b.post_id, 0) = COALESCE($3::INTEGER, 0);`,
+
+	// A bookmark of the same target inserts nothing, even when concurrent with this one
+	createBookmark: `INSERT INTO bookmark (user_nickname, post_id, thread_id, forum_slug, folder, note)
+	VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+	ON CONFLICT (user_nickname, thread_id, COALESCE(post_id, 0)) DO NOTHING
+	RETURNING id, created;`,
+
+	getBookmarksLimit: `SELECT b.id, b.post_id, b.thread_id, b.forum_slug, t.title, COALESCE(p.message, ''), COALESCE(b.folder, ''), b.note, b.created
+	FROM bookmark AS b
+	JOIN thread AS t ON (t.id = b.thread_id)
+	LEFT JOIN post AS p ON (p.id = b.post_id)
+	WHERE b.user_nickname = $1 AND ($3::TEXT IS NULL OR b.folder = $3::TEXT)
+	ORDER BY b.id
+	LIMIT $2;`,
+
+	getBookmarksLimitDesc: `SELECT b.id, b.post_id, b.thread_id, b.forum_slug, t.title, COALESCE(p.message, ''), COALESCE(b.folder, ''), b.note, b.created
+	FROM bookmark AS b
+	JOIN thread AS t ON (t.id = b.thread_id)
+	LEFT JOIN post AS p ON (p.id = b.post_id)
+	WHERE b.user_nickname = $1 AND ($3::TEXT IS NULL OR b.folder = $3::TEXT)
+	ORDER BY b.id DESC
+	LIMIT $2;`,
+
+	getBookmarksLimitSince: `SELECT b.id, b.post_id, b.thread_id, b.forum_slug, t.title, COALESCE(p.message, ''), COALESCE(b.folder, ''), b.note, b.created
+	FROM bookmark AS b
+	JOIN thread AS t ON (t.id = b.thread_id)
+	LEFT JOIN post AS p ON (p.id = b.post_id)
+	WHERE b.user_nickname = $1 AND ($4::TEXT IS NULL OR b.folder = $4::TEXT) AND b.id > $3::TEXT::BIGINT
+	ORDER BY b.id
+	LIMIT $2;`,
+
+	getBookmarksLimitSinceDesc: `SELECT b.id, b.post_id, b.thread_id, b.forum_slug, t.title, COALESCE(p.message, ''), COALESCE(b.folder, ''), b.note, b.created
+	FROM bookmark AS b
+	JOIN thread AS t ON (t.id = b.thread_id)
+	LEFT JOIN post AS p ON (p.id = b.post_id)
+	WHERE b.user_nickname = $1 AND ($4::TEXT IS NULL OR b.folder = $4::TEXT) AND b.id < $3::TEXT::BIGINT
+	ORDER BY b.id DESC
+	LIMIT $2;`,
+
+	countBookmarks: `SELECT COUNT(*)
+	FROM (
+		SELECT 1
+		FROM bookmark
+		WHERE user_nickname = $1 AND ($2::TEXT IS NULL OR folder = $2::TEXT)
+		LIMIT $3
+	) AS capped;`,
+
+	getBookmarkFolders: `SELECT folder, COUNT(*)
+	FROM bookmark
+	WHERE user_nickname = $1 AND folder IS NOT NULL
+	GROUP BY folder
+	ORDER BY folder;`,
+
+	deleteBookmark: `DELETE FROM bookmark
+	WHERE id = $1 AND user_nickname = $2;`,
+
+	deletePostBookmarks: `DELETE FROM bookmark
+	WHERE post_id = $1;`,
+}
+
+func NewBookmarkRepo(conn *pgx.ConnPool) *Bookmark {
+	return &Bookmark{
+		conn: conn,
+	}
+}
+
+type Bookmark struct {
+	conn *pgx.ConnPool
+}
+
+// CreateBookmark saves the post or thread for the user, bookmarking the same target twice returns the existing bookmark
+func (b *Bookmark) CreateBookmark(nickname string, data *bookmark.Create) (*bookmark.Bookmark, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	created := &bookmark.Bookmark{
+		PostID: data.PostID,
+		Folder: data.Folder,
+		Note:   data.Note,
+	}
+
+	if data.PostID != nil {
+		if err := tx.QueryRow(getBookmarkPost, *data.PostID).Scan(&created.ThreadID, &created.ForumSlug, &created.Title, &created.Message); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := tx.QueryRow(getBookmarkThread, *data.Thread).Scan(&created.ThreadID, &created.ForumSlug, &created.Title); err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.QueryRow(createBookmark, nickname, created.PostID, created.ThreadID, created.ForumSlug, data.Folder, data.Note).
+		Scan(&created.ID, &created.Created)
+	if err == pgx.ErrNoRows {
+		existing, err := scanBookmark(tx.QueryRow(getBookmarkByTarget, nickname, created.ThreadID, created.PostID))
+		if err != nil {
+			return nil, err
+		}
+		return existing, errors.New("bookmarkAlreadyExists")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	created.Type = bookmarkType(created)
+	return created, nil
+}
+
+func (b *Bookmark) GetBookmarks(nickname string, folder *string, limit *int, since *string, orderDesc bool) (*bookmark.Bookmarks, error) {
+	if err := b.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = b.conn.Query(getBookmarksLimitDesc, nickname, limit, folder)
+		} else {
+			rows, err = b.conn.Query(getBookmarksLimit, nickname, limit, folder)
+		}
+	} else {
+		if orderDesc {
+			rows, err = b.conn.Query(getBookmarksLimitSinceDesc, nickname, limit, since, folder)
+		} else {
+			rows, err = b.conn.Query(getBookmarksLimitSince, nickname, limit, since, folder)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks := make(bookmark.Bookmarks, 0)
+	for rows.Next() {
+		received, err := scanBookmark(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, *received)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &bookmarks, nil
+}
+
+func (b *Bookmark) CountBookmarks(nickname string, folder *string) (*page.Total, error) {
+	return countCapped(b.conn, countBookmarks, nickname, folder)
+}
+
+func (b *Bookmark) GetFolders(nickname string) (*bookmark.Folders, error) {
+	if err := b.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	rows, err := b.conn.Query(getBookmarkFolders, nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make(bookmark.Folders, 0)
+	for rows.Next() {
+		var received bookmark.Folder
+		rows.Scan(&received.Name, &received.Bookmarks)
+		folders = append(folders, received)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &folders, nil
+}
+
+// DeleteBookmark removes a bookmark of the user, bookmarks of other users are reported as missing
+func (b *Bookmark) DeleteBookmark(nickname string, id uint64) error {
+	tag, err := b.conn.Exec(deleteBookmark, id, nickname)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func scanBookmark(row rowScanner) (*bookmark.Bookmark, error) {
+	received := &bookmark.Bookmark{}
+	if err := row.Scan(&received.ID, &received.PostID, &received.ThreadID, &received.ForumSlug, &received.Title, &received.Message, &received.Folder, &received.Note, &received.Created); err != nil {
+		return nil, err
+	}
+
+	received.Type = bookmarkType(received)
+	return received, nil
+}
+
+func bookmarkType(received *bookmark.Bookmark) string {
+	if received.PostID != nil {
+		return bookmark.TypePost
+	}
+	return bookmark.TypeThread
+}
@@ -0,0 +1,19 @@
+package postgresql
+
+import (
+	"github.com/jackc/pgx"
+)
+
+// ConnPoolConfig is the database shared by the server and the maintenance commands
+func ConnPoolConfig(maxConnections int) pgx.ConnPoolConfig {
+	return pgx.ConnPoolConfig{
+		ConnConfig: pgx.ConnConfig{
+			Host:     "localhost",
+			Port:     5432,
+			Database: "docker",
+			User:     "docker",
+			Password: "docker",
+		},
+		MaxConnections: maxConnections,
+	}
+}
@@ -0,0 +1,362 @@
+package postgresql
+
+import (
+	"log"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/conversation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+
+	"github.com/jackc/pgx"
+)
+
+const (
+	getConversationUsers             = "getConversationUsers"
+	createConversation               = "createConversation"
+	createConversationMembers        = "createConversationMembers"
+	getConversationMember            = "getConversationMember"
+	createPrivateMessage             = "createPrivateMessage"
+	updateConversationMembers        = "updateConversationMembers"
+	getConversationsLimit            = "getConversationsLimit"
+	getConversationsLimitDesc        = "getConversationsLimitDesc"
+	getConversationsLimitSince       = "getConversationsLimitSince"
+	getConversationsLimitSinceDesc   = "getConversationsLimitSinceDesc"
+	countConversations               = "countConversations"
+	getPrivateMessagesLimit          = "getPrivateMessagesLimit"
+	getPrivateMessagesLimitDesc      = "getPrivateMessagesLimitDesc"
+	getPrivateMessagesLimitSince     = "getPrivateMessagesLimitSince"
+	getPrivateMessagesLimitSinceDesc = "getPrivateMessagesLimitSinceDesc"
+	countPrivateMessages             = "countPrivateMessages"
+	markConversationRead             = "markConversationRead"
+	countUnreadPrivateMessages       = "countUnreadPrivateMessages"
+)
+
+var conversationQueries = map[string]string{
+	getConversationUsers: `SELECT nickname
+	FROM client
+	WHERE nickname = ANY($1::TEXT[]::CITEXT[])
+	ORDER BY nickname;`,
+
+	createConversation: `INSERT INTO conversation (subject)
+	VALUES ($1)
+	RETURNING id;`,
+
+	createConversationMembers: `INSERT INTO conversation_member (conversation_id, user_nickname)
+	SELECT $1, unnest($2::TEXT[]);`,
+
+	getConversationMember: `SELECT user_nickname
+	FROM conversation_member
+	WHERE conversation_id = $1 AND user_nickname = $2;`,
+
+	createPrivateMessage: `INSERT INTO private_message (conversation_id, user_nickname, message)
+	VALUES ($1, $2, $3)
+	RETURNING id, conversation_id, user_nickname, message, created;`,
+
+	// The sender has read everything up to its own message, everyone else gets one more unread.
+	// Concurrent senders may commit out of id order, so the positions only ever move forward.
+	updateConversationMembers: `UPDATE conversation_member
+	SET last_message_id = GREATEST(last_message_id, $2),
+		last_read_id = CASE WHEN user_nickname = $3 THEN GREATEST(last_read_id, $2) ELSE last_read_id END,
+		unread = CASE WHEN user_nickname = $3 THEN 0 ELSE unread + 1 END
+	WHERE conversation_id = $1;`,
+
+	getConversationsLimit: `SELECT c.id, c.subject, c.created, m.unread, (
+		SELECT array_agg(p.user_nickname::TEXT ORDER BY p.user_nickname)
+		FROM conversation_member AS p
+		WHERE p.conversation_id = c.id
+	), pm.id, pm.conversation_id, pm.user_nickname, pm.message, pm.created
+	FROM conversation_member AS m
+	JOIN conversation AS c ON (c.id = m.conversation_id)
+	JOIN private_message AS pm ON (pm.id = m.last_message_id)
+	WHERE m.user_nickname = $1 AND (NOT $3::BOOLEAN OR m.unread > 0)
+	ORDER BY m.conversation_id
+	LIMIT $2;`,
+
+	getConversationsLimitDesc: `SELECT c.id, c.subject, c.created, m.unread, (
+		SELECT array_agg(p.user_nickname::TEXT ORDER BY p.user_nickname)
+		FROM conversation_member AS p
+		WHERE p.conversation_id = c.id
+	), pm.id, pm.conversation_id, pm.user_nickname, pm.message, pm.created
+	FROM conversation_member AS m
+	JOIN conversation AS c ON (c.id = m.conversation_id)
+	JOIN private_message AS pm ON (pm.id = m.last_message_id)
+	WHERE m.user_nickname = $1 AND (NOT $3::BOOLEAN OR m.unread > 0)
+	ORDER BY m.conversation_id DESC
+	LIMIT $2;`,
+
+	getConversationsLimitSince: `SELECT c.id, c.subject, c.created, m.unread, (
+		SELECT array_agg(p.user_nickname::TEXT ORDER BY p.user_nickname)
+		FROM conversation_member AS p
+		WHERE p.conversation_id = c.id
+	), pm.id, pm.conversation_id, pm.user_nickname, pm.message, pm.created
+	FROM conversation_member AS m
+	JOIN conversation AS c ON (c.id = m.conversation_id)
+	JOIN private_message AS pm ON (pm.id = m.last_message_id)
+	WHERE m.user_nickname = $1 AND (NOT $4::BOOLEAN OR m.unread > 0) AND m.conversation_id > $3::TEXT::BIGINT
+	ORDER BY m.conversation_id
+	LIMIT $2;`,
+
+	getConversationsLimitSinceDesc: `SELECT c.id, c.subject, c.created, m.unread, (
+		SELECT array_agg(p.user_nickname::TEXT ORDER BY p.user_nickname)
+		FROM conversation_member AS p
+		WHERE p.conversation_id = c.id
+	), pm.id, pm.conversation_id, pm.user_nickname, pm.message, pm.created
+	FROM conversation_member AS m
+	JOIN conversation AS c ON (c.id = m.conversation_id)
+	JOIN private_message AS pm ON (pm.id = m.last_message_id)
+	WHERE m.user_nickname = $1 AND (NOT $4::BOOLEAN OR m.unread > 0) AND m.conversation_id < $3::TEXT::BIGINT
+	ORDER BY m.conversation_id DESC
+	LIMIT $2;`,
+
+	countConversations: `SELECT COUNT(*)
+	FROM (
+		SELECT 1
+		FROM conversation_member
+		WHERE user_nickname = $1 AND (NOT $2::BOOLEAN OR unread > 0)
+		LIMIT $3
+	) AS capped;`,
+
+	getPrivateMessagesLimit: `SELECT id, conversation_id, user_nickname, message, created
+	FROM private_message
+	WHERE conversation_id = $1
+	ORDER BY id
+	LIMIT $2;`,
+
+	getPrivateMessagesLimitDesc: `SELECT id, conversation_id, user_nickname, message, created
+	FROM private_message
+	WHERE conversation_id = $1
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	getPrivateMessagesLimitSince: `SELECT id, conversation_id, user_nickname, message, created
+	FROM private_message
+	WHERE conversation_id = $1 AND id > $3::TEXT::BIGINT
+	ORDER BY id
+	LIMIT $2;`,
+
+	getPrivateMessagesLimitSinceDesc: `SELECT id, conversation_id, user_nickname, message, created
+	FROM private_message
+	WHERE conversation_id = $1 AND id < $3::TEXT::BIGINT
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	countPrivateMessages: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM private_message WHERE conversation_id = $1 LIMIT $2) AS capped;`,
+
+	markConversationRead: `UPDATE conversation_member
+	SET last_read_id = last_message_id, unread = 0
+	WHERE conversation_id = $1 AND user_nickname = $2
+	RETURNING conversation_id;`,
+
+	countUnreadPrivateMessages: `SELECT COALESCE(SUM(unread), 0)
+	FROM conversation_member
+	WHERE user_nickname = $1 AND unread > 0;`,
+}
+
+func NewConversationRepo(conn *pgx.ConnPool) *Conversation {
+	return &Conversation{
+		conn: conn,
+	}
+}
+
+type Conversation struct {
+	conn *pgx.ConnPool
+}
+
+// StartConversation creates a conversation between the sender and the participants with its first message
+func (c *Conversation) StartConversation(nickname string, participants []string, subject, text string) (*conversation.Message, error) {
+	tx, err := c.conn.Begin()
+	if err != nil {
+		log.Println("[Failed] creating transaction. Error:", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(getConversationUsers, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(participants))
+	for rows.Next() {
+		var member string
+		rows.Scan(&member)
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Participants are distinct, so a shorter result means some of them don't exist
+	if len(members) != len(participants) {
+		return nil, pgx.ErrNoRows
+	}
+
+	var id uint64
+	if err := tx.QueryRow(createConversation, subject).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(createConversationMembers, id, members); err != nil {
+		return nil, err
+	}
+
+	sent, err := sendMessage(tx, id, nickname, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return sent, nil
+}
+
+func (c *Conversation) SendMessage(nickname string, id uint64, text string) (*conversation.Message, error) {
+	tx, err := c.conn.Begin()
+	if err != nil {
+		log.Println("[Failed] creating transaction. Error:", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(getConversationMember, id, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	sent, err := sendMessage(tx, id, nickname, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return sent, nil
+}
+
+func sendMessage(tx *pgx.Tx, id uint64, nickname, text string) (*conversation.Message, error) {
+	var sent conversation.Message
+	if err := tx.QueryRow(createPrivateMessage, id, nickname, text).
+		Scan(&sent.ID, &sent.ConversationID, &sent.Author, &sent.Message, &sent.Created); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(updateConversationMembers, id, sent.ID, nickname); err != nil {
+		return nil, err
+	}
+
+	return &sent, nil
+}
+
+// GetConversations pages the member's conversations by id, which unlike the last message doesn't move
+// while the pages are read
+func (c *Conversation) GetConversations(nickname string, limit *int, since *string, orderDesc bool, unread bool) (*conversation.Conversations, error) {
+	if err := c.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	conversations := make(conversation.Conversations, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = c.conn.Query(getConversationsLimitDesc, nickname, limit, unread)
+		} else {
+			rows, err = c.conn.Query(getConversationsLimit, nickname, limit, unread)
+		}
+	} else {
+		if orderDesc {
+			rows, err = c.conn.Query(getConversationsLimitSinceDesc, nickname, limit, since, unread)
+		} else {
+			rows, err = c.conn.Query(getConversationsLimitSince, nickname, limit, since, unread)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row conversation.Conversation
+		rows.Scan(&row.ID, &row.Subject, &row.Created, &row.Unread, &row.Participants,
+			&row.LastMessage.ID, &row.LastMessage.ConversationID, &row.LastMessage.Author, &row.LastMessage.Message, &row.LastMessage.Created)
+		conversations = append(conversations, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &conversations, nil
+}
+
+func (c *Conversation) CountConversations(nickname string, unread bool) (*page.Total, error) {
+	return countCapped(c.conn, countConversations, nickname, unread)
+}
+
+// GetMessages lists a conversation for one of its members, anyone else gets pgx.ErrNoRows
+func (c *Conversation) GetMessages(nickname string, id uint64, limit *int, since *string, orderDesc bool) (*conversation.Messages, error) {
+	if err := c.conn.QueryRow(getConversationMember, id, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	messages := make(conversation.Messages, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = c.conn.Query(getPrivateMessagesLimitDesc, id, limit)
+		} else {
+			rows, err = c.conn.Query(getPrivateMessagesLimit, id, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = c.conn.Query(getPrivateMessagesLimitSinceDesc, id, limit, since)
+		} else {
+			rows, err = c.conn.Query(getPrivateMessagesLimitSince, id, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row conversation.Message
+		rows.Scan(&row.ID, &row.ConversationID, &row.Author, &row.Message, &row.Created)
+		messages = append(messages, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &messages, nil
+}
+
+func (c *Conversation) CountMessages(id uint64) (*page.Total, error) {
+	return countCapped(c.conn, countPrivateMessages, id)
+}
+
+// MarkRead marks a conversation read for the member and returns the unread messages left across all conversations
+func (c *Conversation) MarkRead(nickname string, id uint64) (*conversation.Unread, error) {
+	if err := c.conn.QueryRow(markConversationRead, id, nickname).Scan(&id); err != nil {
+		return nil, err
+	}
+
+	unread := &conversation.Unread{}
+	if err := c.conn.QueryRow(countUnreadPrivateMessages, nickname).Scan(&unread.Count); err != nil {
+		return nil, err
+	}
+
+	return unread, nil
+}
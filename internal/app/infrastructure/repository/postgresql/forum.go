@@ -2,7 +2,10 @@ package postgresql
 
 import (
 	"errors"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 	"github.com/jackc/pgx"
@@ -20,18 +23,41 @@ const (
 	getForumUsersLimitDesc      = "getForumUsersLimitDesc"
 	getForumUsersLimitSince     = "getForumUsersLimitSince"
 	getForumUsersLimitSinceDesc = "getForumUsersLimitSinceDesc"
+	getForumLeadersLimit        = "getForumLeadersLimit"
+	getForumLeadersLimitSince   = "getForumLeadersLimitSince"
+	getForumLeadersLimitAfter   = "getForumLeadersLimitAfter"
+	getForumsByTitle            = "getForumsByTitle"
+	getForumsByTitleDesc        = "getForumsByTitleDesc"
+	getForumsByTitleAfter       = "getForumsByTitleAfter"
+	getForumsByTitleAfterDesc   = "getForumsByTitleAfterDesc"
+	getForumsByCreated          = "getForumsByCreated"
+	getForumsByCreatedDesc      = "getForumsByCreatedDesc"
+	getForumsByCreatedAfter     = "getForumsByCreatedAfter"
+	getForumsByCreatedAfterDesc = "getForumsByCreatedAfterDesc"
+	getForumsByThreads          = "getForumsByThreads"
+	getForumsByThreadsDesc      = "getForumsByThreadsDesc"
+	getForumsByThreadsAfter     = "getForumsByThreadsAfter"
+	getForumsByThreadsAfterDesc = "getForumsByThreadsAfterDesc"
+	getForumsByPosts            = "getForumsByPosts"
+	getForumsByPostsDesc        = "getForumsByPostsDesc"
+	getForumsByPostsAfter       = "getForumsByPostsAfter"
+	getForumsByPostsAfterDesc   = "getForumsByPostsAfterDesc"
+	countForumUsers             = "countForumUsers"
+	countForums                 = "countForums"
+	getForumTree                = "getForumTree"
+	getForumHierarchy           = "getForumHierarchy"
 )
 
 var forumQueries = map[string]string{
-	getForumBySlug: `SELECT slug, title, posts, threads, user_nickname
+	getForumBySlug: `SELECT slug, title, posts, threads, user_nickname, parent_slug, category
 	FROM forum
 	WHERE slug = $1;`,
 
-	createForum: `INSERT INTO forum (slug, title, user_nickname) 
-	VALUES ($1, $2, $3)
-	RETURNING slug, title, posts, threads, user_nickname;`,
+	createForum: `INSERT INTO forum (slug, title, user_nickname, parent_slug, category)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING slug, title, posts, threads, user_nickname, parent_slug, category;`,
 
-	getForumIdAndSlugBySlug: `SELECT id, slug
+	getForumIdAndSlugBySlug: `SELECT id, slug, category
 	FROM forum
 	WHERE slug = $1;`,
 
@@ -75,6 +101,166 @@ var forumQueries = map[string]string{
 	WHERE forum_slug = $1 AND nickname < $3
 	ORDER BY nickname DESC
 	LIMIT $2;`,
+
+	// Leaders are ranked by the reputation earned in the forum, posts and threads stay overall
+	getForumLeadersLimit: `SELECT c.email, c.nickname, c.fullname, c.about, fc.reputation, c.posts, c.threads
+	FROM forum_client AS fc
+	JOIN client AS c ON (c.nickname = fc.nickname)
+	WHERE fc.forum_slug = $1
+	ORDER BY fc.reputation DESC, fc.nickname
+	LIMIT $2;`,
+
+	getForumLeadersLimitSince: `SELECT c.email, c.nickname, c.fullname, c.about, fc.reputation, c.posts, c.threads
+	FROM forum_client AS fc
+	JOIN client AS c ON (c.nickname = fc.nickname)
+	JOIN forum_client AS s ON (s.forum_slug = fc.forum_slug AND s.nickname = $3)
+	WHERE fc.forum_slug = $1
+		AND (fc.reputation < s.reputation OR (fc.reputation = s.reputation AND fc.nickname > s.nickname))
+	ORDER BY fc.reputation DESC, fc.nickname
+	LIMIT $2;`,
+
+	getForumLeadersLimitAfter: `SELECT c.email, c.nickname, c.fullname, c.about, fc.reputation, c.posts, c.threads
+	FROM forum_client AS fc
+	JOIN client AS c ON (c.nickname = fc.nickname)
+	WHERE fc.forum_slug = $1
+		AND (fc.reputation < $3::TEXT::INTEGER OR (fc.reputation = $3::TEXT::INTEGER AND fc.nickname > $4))
+	ORDER BY fc.reputation DESC, fc.nickname
+	LIMIT $2;`,
+
+	getForumsByTitle: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (title, id) > (SELECT title, id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY title, id
+	LIMIT $2;`,
+
+	getForumsByTitleDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (title, id) < (SELECT title, id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY title DESC, id DESC
+	LIMIT $2;`,
+
+	getForumsByCreated: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (id) > (SELECT id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY id
+	LIMIT $2;`,
+
+	getForumsByCreatedDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (id) < (SELECT id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	getForumsByThreads: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (threads, id) > (SELECT threads, id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY threads, id
+	LIMIT $2;`,
+
+	getForumsByThreadsDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (threads, id) < (SELECT threads, id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY threads DESC, id DESC
+	LIMIT $2;`,
+
+	getForumsByPosts: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (posts, id) > (SELECT posts, id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY posts, id
+	LIMIT $2;`,
+
+	getForumsByPostsDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND ($3::CITEXT IS NULL OR (posts, id) < (SELECT posts, id FROM forum WHERE slug = $3::CITEXT))
+	ORDER BY posts DESC, id DESC
+	LIMIT $2;`,
+
+	getForumsByTitleAfter: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND (title, id) > ($3::TEXT, $4)
+	ORDER BY title, id
+	LIMIT $2;`,
+
+	getForumsByTitleAfterDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND (title, id) < ($3::TEXT, $4)
+	ORDER BY title DESC, id DESC
+	LIMIT $2;`,
+
+	getForumsByCreatedAfter: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND id > $3::TEXT::INTEGER
+	ORDER BY id
+	LIMIT $2;`,
+
+	getForumsByCreatedAfterDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND id < $3::TEXT::INTEGER
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	getForumsByThreadsAfter: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND (threads, id) > ($3::TEXT::INTEGER, $4)
+	ORDER BY threads, id
+	LIMIT $2;`,
+
+	getForumsByThreadsAfterDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND (threads, id) < ($3::TEXT::INTEGER, $4)
+	ORDER BY threads DESC, id DESC
+	LIMIT $2;`,
+
+	getForumsByPostsAfter: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND (posts, id) > ($3::TEXT::BIGINT, $4)
+	ORDER BY posts, id
+	LIMIT $2;`,
+
+	getForumsByPostsAfterDesc: `SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	WHERE ($1::CITEXT IS NULL OR user_nickname = $1::CITEXT)
+		AND (posts, id) < ($3::TEXT::BIGINT, $4)
+	ORDER BY posts DESC, id DESC
+	LIMIT $2;`,
+
+	countForumUsers: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM forum_client WHERE forum_slug = $1 LIMIT $2) AS capped;`,
+
+	countForums: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM forum WHERE $1::CITEXT IS NULL OR user_nickname = $1::CITEXT LIMIT $2) AS capped;`,
+
+	getForumTree: `WITH RECURSIVE tree AS (
+		SELECT id, slug, title, posts, threads, user_nickname, parent_slug, category
+		FROM forum
+		WHERE slug = $1
+		UNION ALL
+		SELECT f.id, f.slug, f.title, f.posts, f.threads, f.user_nickname, f.parent_slug, f.category
+		FROM forum AS f
+		JOIN tree AS t ON (f.parent_slug = t.slug)
+	)
+	SELECT slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM tree
+	ORDER BY title, id;`,
+
+	getForumHierarchy: `SELECT slug, title, posts, threads, user_nickname, parent_slug, category
+	FROM forum
+	ORDER BY title, id;`,
 }
 
 func NewForumRepo(conn *pgx.ConnPool) *Forum {
@@ -89,14 +275,14 @@ type Forum struct {
 
 func (f *Forum) GetForum(slug string) (*forum.Forum, error) {
 	received := &forum.Forum{}
-	if err := f.conn.QueryRow(getForumBySlug, slug).Scan(&received.Slug, &received.Title, &received.Posts, &received.Threads, &received.UserNickname); err != nil {
+	if err := f.conn.QueryRow(getForumBySlug, slug).Scan(&received.Slug, &received.Title, &received.Posts, &received.Threads, &received.UserNickname, &received.Parent, &received.Category); err != nil {
 		return nil, err
 	}
 
 	return received, nil
 }
 
-func (f *Forum) CreateForum(data *forum.Create) (*forum.Forum, error) {
+func (f *Forum) CreateForum(data *forum.Create, emit func(*forum.Forum) event.Typed) (*forum.Forum, error) {
 	tx, err := f.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -110,12 +296,23 @@ func (f *Forum) CreateForum(data *forum.Create) (*forum.Forum, error) {
 
 	forum := &forum.Forum{}
 	if err := tx.QueryRow(getForumBySlug, data.Slug).
-		Scan(&forum.Slug, &forum.Title, &forum.Posts, &forum.Threads, &forum.UserNickname); err == nil {
+		Scan(&forum.Slug, &forum.Title, &forum.Posts, &forum.Threads, &forum.UserNickname, &forum.Parent, &forum.Category); err == nil {
 		return forum, errors.New("ForumAlreadyExists")
 	}
 
-	if err := tx.QueryRow(createForum, data.Slug, data.Title, data.UserNickname).
-		Scan(&forum.Slug, &forum.Title, &forum.Posts, &forum.Threads, &forum.UserNickname); err != nil {
+	// Check parent existence, the parent is stored in its registered spelling
+	if data.Parent != nil {
+		if err := tx.QueryRow(getForumSlugBySlug, *data.Parent).Scan(data.Parent); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.QueryRow(createForum, data.Slug, data.Title, data.UserNickname, data.Parent, data.Category).
+		Scan(&forum.Slug, &forum.Title, &forum.Posts, &forum.Threads, &forum.UserNickname, &forum.Parent, &forum.Category); err != nil {
+		return nil, err
+	}
+
+	if err := recordEvent(tx, emit(forum)); err != nil {
 		return nil, err
 	}
 
@@ -165,3 +362,179 @@ func (f *Forum) GetForumUsers(slug string, limit *int, since *string, orderDesc
 
 	return &users, nil
 }
+
+func (f *Forum) GetForumLeaders(slug string, limit *int, since *string, after *cursor.Cursor) (*user.Users, error) {
+	if err := f.conn.QueryRow(getForumSlugBySlug, slug).Scan(&slug); err != nil {
+		return nil, err
+	}
+
+	users := make(user.Users, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if after != nil {
+		rows, err = f.conn.Query(getForumLeadersLimitAfter, slug, limit, after.Key, after.Name)
+	} else if since == nil {
+		rows, err = f.conn.Query(getForumLeadersLimit, slug, limit)
+	} else {
+		rows, err = f.conn.Query(getForumLeadersLimitSince, slug, limit, since)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var received user.User
+		rows.Scan(&received.Email, &received.Nickname, &received.Fullname, &received.About, &received.Reputation, &received.Posts, &received.Threads)
+		users = append(users, received)
+	}
+
+	return &users, nil
+}
+
+func (f *Forum) ListForums(creator *string, sort string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*forum.Forums, error) {
+	if after != nil {
+		return f.listForumsAfter(creator, sort, limit, after, orderDesc)
+	}
+
+	var query string
+	switch sort {
+	case forum.SortByTitle:
+		if orderDesc {
+			query = getForumsByTitleDesc
+		} else {
+			query = getForumsByTitle
+		}
+	case forum.SortByThreads:
+		if orderDesc {
+			query = getForumsByThreadsDesc
+		} else {
+			query = getForumsByThreads
+		}
+	case forum.SortByPosts:
+		if orderDesc {
+			query = getForumsByPostsDesc
+		} else {
+			query = getForumsByPosts
+		}
+	default:
+		if orderDesc {
+			query = getForumsByCreatedDesc
+		} else {
+			query = getForumsByCreated
+		}
+	}
+
+	rows, err := f.conn.Query(query, creator, limit, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanListedForums(rows)
+}
+
+// listForumsAfter continues a listing from the sort value and id carried by the cursor
+func (f *Forum) listForumsAfter(creator *string, sort string, limit *int, after *cursor.Cursor, orderDesc bool) (*forum.Forums, error) {
+	var rows *pgx.Rows
+	var err error
+	switch sort {
+	case forum.SortByTitle:
+		if orderDesc {
+			rows, err = f.conn.Query(getForumsByTitleAfterDesc, creator, limit, after.Key, after.ID)
+		} else {
+			rows, err = f.conn.Query(getForumsByTitleAfter, creator, limit, after.Key, after.ID)
+		}
+	case forum.SortByThreads:
+		if orderDesc {
+			rows, err = f.conn.Query(getForumsByThreadsAfterDesc, creator, limit, after.Key, after.ID)
+		} else {
+			rows, err = f.conn.Query(getForumsByThreadsAfter, creator, limit, after.Key, after.ID)
+		}
+	case forum.SortByPosts:
+		if orderDesc {
+			rows, err = f.conn.Query(getForumsByPostsAfterDesc, creator, limit, after.Key, after.ID)
+		} else {
+			rows, err = f.conn.Query(getForumsByPostsAfter, creator, limit, after.Key, after.ID)
+		}
+	default:
+		if orderDesc {
+			rows, err = f.conn.Query(getForumsByCreatedAfterDesc, creator, limit, after.Key)
+		} else {
+			rows, err = f.conn.Query(getForumsByCreatedAfter, creator, limit, after.Key)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanListedForums(rows)
+}
+
+func (f *Forum) CountForumUsers(slug string) (*page.Total, error) {
+	return countCapped(f.conn, countForumUsers, slug)
+}
+
+func (f *Forum) CountForums(creator *string) (*page.Total, error) {
+	return countCapped(f.conn, countForums, creator)
+}
+
+// GetForumTree returns the forum followed by all of its subforums, each level ordered by title
+func (f *Forum) GetForumTree(slug string) (*forum.Forums, error) {
+	rows, err := f.conn.Query(getForumTree, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	forums, err := scanForums(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*forums) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+
+	return forums, nil
+}
+
+// GetForumHierarchy returns every forum, the caller links them through their parents
+func (f *Forum) GetForumHierarchy() (*forum.Forums, error) {
+	rows, err := f.conn.Query(getForumHierarchy)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanForums(rows)
+}
+
+func scanListedForums(rows *pgx.Rows) (*forum.Forums, error) {
+	forums := make(forum.Forums, 0)
+	for rows.Next() {
+		var received forum.Forum
+		rows.Scan(&received.ID, &received.Slug, &received.Title, &received.Posts, &received.Threads, &received.UserNickname, &received.Parent, &received.Category)
+		forums = append(forums, received)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &forums, nil
+}
+
+func scanForums(rows *pgx.Rows) (*forum.Forums, error) {
+	forums := make(forum.Forums, 0)
+	for rows.Next() {
+		var received forum.Forum
+		rows.Scan(&received.Slug, &received.Title, &received.Posts, &received.Threads, &received.UserNickname, &received.Parent, &received.Category)
+		forums = append(forums, received)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &forums, nil
+}
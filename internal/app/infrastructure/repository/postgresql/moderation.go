@@ -0,0 +1,407 @@
+package postgresql
+
+import (
+	"errors"
+	"log"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/moderation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
+
+	"github.com/jackc/pgx"
+)
+
+const (
+	getForumRole                       = "getForumRole"
+	getModerators                      = "getModerators"
+	addModerator                       = "addModerator"
+	removeModerator                    = "removeModerator"
+	reportPostItem                     = "reportPostItem"
+	createPostReport                   = "createPostReport"
+	getModerationItem                  = "getModerationItem"
+	getModerationQueueLimit            = "getModerationQueueLimit"
+	getModerationQueueLimitDesc        = "getModerationQueueLimitDesc"
+	getModerationQueueLimitSince       = "getModerationQueueLimitSince"
+	getModerationQueueLimitSinceDesc   = "getModerationQueueLimitSinceDesc"
+	countModerationQueue               = "countModerationQueue"
+	resolveModerationItem              = "resolveModerationItem"
+	createModerationAction             = "createModerationAction"
+	getModerationActionsLimit          = "getModerationActionsLimit"
+	getModerationActionsLimitDesc      = "getModerationActionsLimitDesc"
+	getModerationActionsLimitSince     = "getModerationActionsLimitSince"
+	getModerationActionsLimitSinceDesc = "getModerationActionsLimitSinceDesc"
+	countModerationActions             = "countModerationActions"
+)
+
+var moderationQueries = map[string]string{
+	getForumRole: `SELECT CASE
+		WHEN f.user_nickname = $2 THEN 'owner'
+		WHEN EXISTS(SELECT 1 FROM forum_moderator AS m WHERE m.forum_slug = f.slug AND m.user_nickname = $2) THEN 'moderator'
+		ELSE ''
+	END
+	FROM forum AS f
+	WHERE f.slug = $1;`,
+
+	getModerators: `SELECT user_nickname, added_by, created
+	FROM forum_moderator
+	WHERE forum_slug = $1
+	ORDER BY user_nickname;`,
+
+	// Adding a moderator twice keeps the original entry
+	addModerator: `INSERT INTO forum_moderator (forum_slug, user_nickname, added_by)
+	SELECT f.slug, c.nickname, $3
+	FROM forum AS f, client AS c
+	WHERE f.slug = $1 AND c.nickname = $2
+	ON CONFLICT (forum_slug, user_nickname) DO UPDATE SET added_by = forum_moderator.added_by
+	RETURNING user_nickname, added_by, created;`,
+
+	removeModerator: `DELETE FROM forum_moderator
+	WHERE forum_slug = $1 AND user_nickname = $2
+	RETURNING user_nickname;`,
+
+	// Further reports of a post pile up on its open item
+	reportPostItem: `INSERT INTO moderation_item (forum_slug, kind, target_id, thread_id, user_nickname, message, reasons, reports)
+	SELECT forum_slug, 'report', id, thread_id, user_nickname, message, ARRAY[$2::TEXT], 1
+	FROM post
+	WHERE id = $1
+	ON CONFLICT (kind, target_id) WHERE NOT resolved
+	DO UPDATE SET reports = moderation_item.reports + 1, reasons = moderation_item.reasons || EXCLUDED.reasons
+	RETURNING id, kind, target_id, thread_id, forum_slug, user_nickname, message, reasons, reports, created;`,
+
+	createPostReport: `INSERT INTO post_report (post_id, user_nickname, reason)
+	SELECT $1, nickname, $3
+	FROM client
+	WHERE nickname = $2
+	ON CONFLICT (post_id, user_nickname) DO NOTHING
+	RETURNING post_id;`,
+
+	getModerationItem: `SELECT id, kind, target_id, thread_id, forum_slug, user_nickname, message, reasons, reports, created
+	FROM moderation_item
+	WHERE id = $1 AND forum_slug = $2 AND NOT resolved;`,
+
+	getModerationQueueLimit: `SELECT id, kind, target_id, thread_id, forum_slug, user_nickname, message, reasons, reports, created
+	FROM moderation_item
+	WHERE forum_slug = $1 AND NOT resolved
+	ORDER BY id
+	LIMIT $2;`,
+
+	getModerationQueueLimitDesc: `SELECT id, kind, target_id, thread_id, forum_slug, user_nickname, message, reasons, reports, created
+	FROM moderation_item
+	WHERE forum_slug = $1 AND NOT resolved
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	getModerationQueueLimitSince: `SELECT id, kind, target_id, thread_id, forum_slug, user_nickname, message, reasons, reports, created
+	FROM moderation_item
+	WHERE forum_slug = $1 AND NOT resolved AND id > $3::TEXT::BIGINT
+	ORDER BY id
+	LIMIT $2;`,
+
+	getModerationQueueLimitSinceDesc: `SELECT id, kind, target_id, thread_id, forum_slug, user_nickname, message, reasons, reports, created
+	FROM moderation_item
+	WHERE forum_slug = $1 AND NOT resolved AND id < $3::TEXT::BIGINT
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	countModerationQueue: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM moderation_item WHERE forum_slug = $1 AND NOT resolved LIMIT $2) AS capped;`,
+
+	resolveModerationItem: `UPDATE moderation_item
+	SET resolved = TRUE
+	WHERE id = $1 AND NOT resolved
+	RETURNING forum_slug, user_nickname;`,
+
+	createModerationAction: `INSERT INTO moderation_action (item_id, forum_slug, moderator, action, note, user_nickname)
+	SELECT $1, $2, nickname, $4, $5, $6
+	FROM client
+	WHERE nickname = $3
+	RETURNING id, item_id, forum_slug, moderator, action, note, user_nickname, created;`,
+
+	getModerationActionsLimit: `SELECT id, item_id, forum_slug, moderator, action, note, user_nickname, created
+	FROM moderation_action
+	WHERE forum_slug = $1
+	ORDER BY id
+	LIMIT $2;`,
+
+	getModerationActionsLimitDesc: `SELECT id, item_id, forum_slug, moderator, action, note, user_nickname, created
+	FROM moderation_action
+	WHERE forum_slug = $1
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	getModerationActionsLimitSince: `SELECT id, item_id, forum_slug, moderator, action, note, user_nickname, created
+	FROM moderation_action
+	WHERE forum_slug = $1 AND id > $3::TEXT::BIGINT
+	ORDER BY id
+	LIMIT $2;`,
+
+	getModerationActionsLimitSinceDesc: `SELECT id, item_id, forum_slug, moderator, action, note, user_nickname, created
+	FROM moderation_action
+	WHERE forum_slug = $1 AND id < $3::TEXT::BIGINT
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	countModerationActions: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM moderation_action WHERE forum_slug = $1 LIMIT $2) AS capped;`,
+}
+
+func NewModerationRepo(conn *pgx.ConnPool) *Moderation {
+	return &Moderation{
+		conn: conn,
+	}
+}
+
+type Moderation struct {
+	conn *pgx.ConnPool
+}
+
+// GetRole tells whether nickname owns or moderates the forum, the role is empty for anyone else
+func (m *Moderation) GetRole(slug, nickname string) (string, error) {
+	var role string
+	if err := m.conn.QueryRow(getForumRole, slug, nickname).Scan(&role); err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (m *Moderation) GetModerators(slug string) (*moderation.Moderators, error) {
+	if err := m.conn.QueryRow(getForumSlugBySlug, slug).Scan(&slug); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.conn.Query(getModerators, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	moderators := make(moderation.Moderators, 0)
+	for rows.Next() {
+		var row moderation.Moderator
+		rows.Scan(&row.Nickname, &row.AddedBy, &row.Created)
+		moderators = append(moderators, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &moderators, nil
+}
+
+func (m *Moderation) AddModerator(slug, nickname, addedBy string) (*moderation.Moderator, error) {
+	var moderator moderation.Moderator
+	if err := m.conn.QueryRow(addModerator, slug, nickname, addedBy).
+		Scan(&moderator.Nickname, &moderator.AddedBy, &moderator.Created); err != nil {
+		return nil, err
+	}
+
+	return &moderator, nil
+}
+
+func (m *Moderation) RemoveModerator(slug, nickname string) error {
+	return m.conn.QueryRow(removeModerator, slug, nickname).Scan(&nickname)
+}
+
+// ReportPost opens or extends the queue item of a post, every user reports a post once
+func (m *Moderation) ReportPost(id uint64, data *moderation.Report) (*moderation.Item, error) {
+	tx, err := m.conn.Begin()
+	if err != nil {
+		log.Println("[Failed] creating transaction. Error:", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	item, err := scanItem(tx.QueryRow(reportPostItem, id, data.Reason))
+	if err != nil {
+		return nil, err
+	}
+
+	var nickname string
+	if err := tx.QueryRow(getUserByNickname, data.UserNickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	var reported uint64
+	if err := tx.QueryRow(createPostReport, id, nickname, data.Reason).Scan(&reported); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.New("postAlreadyReported")
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (m *Moderation) GetItem(slug string, id uint64) (*moderation.Item, error) {
+	return scanItem(m.conn.QueryRow(getModerationItem, id, slug))
+}
+
+func (m *Moderation) GetQueue(slug string, limit *int, since *string, orderDesc bool) (*moderation.Items, error) {
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = m.conn.Query(getModerationQueueLimitDesc, slug, limit)
+		} else {
+			rows, err = m.conn.Query(getModerationQueueLimit, slug, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = m.conn.Query(getModerationQueueLimitSinceDesc, slug, limit, since)
+		} else {
+			rows, err = m.conn.Query(getModerationQueueLimitSince, slug, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(moderation.Items, 0)
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &items, nil
+}
+
+func (m *Moderation) CountQueue(slug string) (*page.Total, error) {
+	return countCapped(m.conn, countModerationQueue, slug)
+}
+
+// ResolveItem claims an open item, applies the decision to the post behind it and records it,
+// a ban also bans the author from the forum. A dismissed quarantine item publishes held.
+func (m *Moderation) ResolveItem(item *moderation.Item, data *moderation.Decision, held *post.Create, emit func(*post.Post) event.Typed) (*moderation.Action, error) {
+	tx, err := m.conn.Begin()
+	if err != nil {
+		log.Println("[Failed] creating transaction. Error:", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Claiming first makes a concurrent decision on the same item fail before touching the post
+	var forumSlug, author string
+	if err := tx.QueryRow(resolveModerationItem, item.ID).Scan(&forumSlug, &author); err != nil {
+		return nil, err
+	}
+
+	var released *map[string]user.Info
+	var releasedForum string
+
+	switch item.Kind {
+	case moderation.KindReport:
+		switch data.Action {
+		case moderation.ActionHide, moderation.ActionBan:
+			err = hideModeratedPost(tx, item.Target)
+		case moderation.ActionDelete:
+			err = deleteModeratedPost(tx, item.Target)
+		}
+	case moderation.KindQuarantine:
+		if data.Action == moderation.ActionDismiss && held != nil {
+			_, releasedForum, released, err = releaseQuarantined(tx, item.Target, item.ThreadID, held, emit)
+		} else {
+			err = tx.QueryRow(deletePostQuarantine, item.Target).Scan(&item.Target)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var action moderation.Action
+	if err := tx.QueryRow(createModerationAction, item.ID, forumSlug, data.Moderator, data.Action, data.Note, author).
+		Scan(&action.ID, &action.ItemID, &action.ForumSlug, &action.Moderator, &action.Action, &action.Note, &action.UserNickname, &action.Created); err != nil {
+		return nil, err
+	}
+
+	if data.Action == moderation.ActionBan {
+		if _, err := banFromForum(tx, author, forumSlug, data.Note, action.Moderator); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if released != nil {
+		if err := createdPosts(m.conn, releasedForum, released, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	return &action, nil
+}
+
+func (m *Moderation) GetActions(slug string, limit *int, since *string, orderDesc bool) (*moderation.Actions, error) {
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = m.conn.Query(getModerationActionsLimitDesc, slug, limit)
+		} else {
+			rows, err = m.conn.Query(getModerationActionsLimit, slug, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = m.conn.Query(getModerationActionsLimitSinceDesc, slug, limit, since)
+		} else {
+			rows, err = m.conn.Query(getModerationActionsLimitSince, slug, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make(moderation.Actions, 0)
+	for rows.Next() {
+		var row moderation.Action
+		rows.Scan(&row.ID, &row.ItemID, &row.ForumSlug, &row.Moderator, &row.Action, &row.Note, &row.UserNickname, &row.Created)
+		actions = append(actions, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &actions, nil
+}
+
+func (m *Moderation) CountActions(slug string) (*page.Total, error) {
+	return countCapped(m.conn, countModerationActions, slug)
+}
+
+// scanItem reads the item columns shared by the queue queries, only reports point at a post
+func scanItem(row rowScanner) (*moderation.Item, error) {
+	var item moderation.Item
+	if err := row.Scan(&item.ID, &item.Kind, &item.Target, &item.ThreadID, &item.ForumSlug, &item.UserNickname,
+		&item.Message, &item.Reasons, &item.Reports, &item.Created); err != nil {
+		return nil, err
+	}
+
+	if item.Kind == moderation.KindReport {
+		postID := item.Target
+		item.PostID = &postID
+	}
+
+	return &item, nil
+}
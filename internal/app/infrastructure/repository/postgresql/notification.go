@@ -0,0 +1,298 @@
+package postgresql
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/notification"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
+	"github.com/jackc/pgx"
+)
+
+const (
+	createThreadSubscription       = "createThreadSubscription"
+	deleteThreadSubscription       = "deleteThreadSubscription"
+	createForumSubscription        = "createForumSubscription"
+	deleteForumSubscription        = "deleteForumSubscription"
+	notifyReply                    = "notifyReply"
+	notifyMentions                 = "notifyMentions"
+	notifyThreadSubscribers        = "notifyThreadSubscribers"
+	notifyForumSubscribers         = "notifyForumSubscribers"
+	getNotificationsLimit          = "getNotificationsLimit"
+	getNotificationsLimitDesc      = "getNotificationsLimitDesc"
+	getNotificationsLimitSince     = "getNotificationsLimitSince"
+	getNotificationsLimitSinceDesc = "getNotificationsLimitSinceDesc"
+	countNotifications             = "countNotifications"
+	markNotificationsRead          = "markNotificationsRead"
+	countUnreadNotifications       = "countUnreadNotifications"
+)
+
+var notificationQueries = map[string]string{
+	createThreadSubscription: `INSERT INTO thread_subscription (user_nickname, thread_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING;`,
+
+	deleteThreadSubscription: `DELETE FROM thread_subscription
+	WHERE user_nickname = $1 AND thread_id = $2
+	RETURNING user_nickname;`,
+
+	createForumSubscription: `INSERT INTO forum_subscription (user_nickname, forum_slug)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING;`,
+
+	deleteForumSubscription: `DELETE FROM forum_subscription
+	WHERE user_nickname = $1 AND forum_slug = $2
+	RETURNING user_nickname;`,
+
+	// Nobody is notified about their own posts. Replies, mentions and followed thread posts are
+	// inserted in that order and the event index keeps the first notification of each user
+	notifyReply: `INSERT INTO notification (user_nickname, event_id, kind, author, forum_slug, thread_id, post_id)
+	SELECT user_nickname, $1, 'reply', $2, $3, $4, $5
+	FROM post
+	WHERE id = $6 AND user_nickname <> $2
+	ON CONFLICT (user_nickname, event_id) DO NOTHING;`,
+
+	// Edits only notify users not yet told they were mentioned in or answered by the post
+	notifyMentions: `INSERT INTO notification (user_nickname, event_id, kind, author, forum_slug, thread_id, post_id)
+	SELECT m.nickname, $1, 'mention', $2, $3, $4, $5
+	FROM unnest($6::TEXT[]) AS m(nickname)
+	WHERE m.nickname::CITEXT <> $2::CITEXT AND NOT EXISTS (
+		SELECT 1
+		FROM notification AS n
+		WHERE n.user_nickname = m.nickname::CITEXT AND n.post_id = $5 AND n.kind IN ('reply', 'mention')
+	)
+	ON CONFLICT (user_nickname, event_id) DO NOTHING;`,
+
+	notifyThreadSubscribers: `INSERT INTO notification (user_nickname, event_id, kind, author, forum_slug, thread_id, post_id)
+	SELECT user_nickname, $1, 'post', $2, $3, $4, $5
+	FROM thread_subscription
+	WHERE thread_id = $4 AND user_nickname <> $2
+	ON CONFLICT (user_nickname, event_id) DO NOTHING;`,
+
+	notifyForumSubscribers: `INSERT INTO notification (user_nickname, event_id, kind, author, forum_slug, thread_id)
+	SELECT user_nickname, $1, 'thread', $2, $3, $4
+	FROM forum_subscription
+	WHERE forum_slug = $3 AND user_nickname <> $2
+	ON CONFLICT (user_nickname, event_id) DO NOTHING;`,
+
+	getNotificationsLimit: `SELECT id, kind, author, forum_slug, thread_id, post_id, read, created
+	FROM notification
+	WHERE user_nickname = $1 AND (NOT $3::BOOLEAN OR NOT read)
+	ORDER BY id
+	LIMIT $2;`,
+
+	getNotificationsLimitDesc: `SELECT id, kind, author, forum_slug, thread_id, post_id, read, created
+	FROM notification
+	WHERE user_nickname = $1 AND (NOT $3::BOOLEAN OR NOT read)
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	getNotificationsLimitSince: `SELECT id, kind, author, forum_slug, thread_id, post_id, read, created
+	FROM notification
+	WHERE user_nickname = $1 AND (NOT $4::BOOLEAN OR NOT read) AND id > $3::TEXT::BIGINT
+	ORDER BY id
+	LIMIT $2;`,
+
+	getNotificationsLimitSinceDesc: `SELECT id, kind, author, forum_slug, thread_id, post_id, read, created
+	FROM notification
+	WHERE user_nickname = $1 AND (NOT $4::BOOLEAN OR NOT read) AND id < $3::TEXT::BIGINT
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	countNotifications: `SELECT COUNT(*)
+	FROM (
+		SELECT 1
+		FROM notification
+		WHERE user_nickname = $1 AND (NOT $2::BOOLEAN OR NOT read)
+		LIMIT $3
+	) AS capped;`,
+
+	// Without ids every unread notification of the user is marked
+	markNotificationsRead: `UPDATE notification
+	SET read = TRUE
+	WHERE user_nickname = $1 AND NOT read AND ($2::BIGINT[] IS NULL OR id = ANY($2::BIGINT[]));`,
+
+	countUnreadNotifications: `SELECT COUNT(*)
+	FROM notification
+	WHERE user_nickname = $1 AND NOT read;`,
+}
+
+func NewNotificationRepo(conn *pgx.ConnPool) *Notification {
+	return &Notification{
+		conn: conn,
+	}
+}
+
+type Notification struct {
+	conn *pgx.ConnPool
+}
+
+func (n *Notification) SubscribeThread(slugOrId string, nickname string) (*notification.Subscription, error) {
+	subscription := &notification.Subscription{}
+	if err := n.conn.QueryRow(getUserByNickname, nickname).Scan(&subscription.UserNickname); err != nil {
+		return nil, err
+	}
+
+	var slug *string
+	if err := n.conn.QueryRow(checkThreadByIdOrSlug, slugOrId).Scan(&subscription.ThreadID, &slug); err != nil {
+		return nil, err
+	}
+
+	if _, err := n.conn.Exec(createThreadSubscription, subscription.UserNickname, subscription.ThreadID); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (n *Notification) UnsubscribeThread(slugOrId string, nickname string) (*notification.Subscription, error) {
+	subscription := &notification.Subscription{}
+
+	var slug *string
+	if err := n.conn.QueryRow(checkThreadByIdOrSlug, slugOrId).Scan(&subscription.ThreadID, &slug); err != nil {
+		return nil, err
+	}
+
+	if err := n.conn.QueryRow(deleteThreadSubscription, nickname, subscription.ThreadID).Scan(&subscription.UserNickname); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (n *Notification) SubscribeForum(slug string, nickname string) (*notification.Subscription, error) {
+	subscription := &notification.Subscription{}
+	if err := n.conn.QueryRow(getUserByNickname, nickname).Scan(&subscription.UserNickname); err != nil {
+		return nil, err
+	}
+
+	if err := n.conn.QueryRow(getForumSlugBySlug, slug).Scan(&subscription.ForumSlug); err != nil {
+		return nil, err
+	}
+
+	if _, err := n.conn.Exec(createForumSubscription, subscription.UserNickname, subscription.ForumSlug); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (n *Notification) UnsubscribeForum(slug string, nickname string) (*notification.Subscription, error) {
+	subscription := &notification.Subscription{}
+	if err := n.conn.QueryRow(getForumSlugBySlug, slug).Scan(&subscription.ForumSlug); err != nil {
+		return nil, err
+	}
+
+	if err := n.conn.QueryRow(deleteForumSubscription, nickname, subscription.ForumSlug).Scan(&subscription.UserNickname); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// NotifyPost records notifications about a created post, eventID makes redelivered events a no-op
+func (n *Notification) NotifyPost(eventID uint64, created *post.Post) error {
+	tx, err := n.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if created.Parent != 0 {
+		if _, err := tx.Exec(notifyReply, eventID, created.UserNickname, created.ForumSlug, created.ThreadID, created.ID, created.Parent); err != nil {
+			return err
+		}
+	}
+
+	if len(created.Mentions) != 0 {
+		if _, err := tx.Exec(notifyMentions, eventID, created.UserNickname, created.ForumSlug, created.ThreadID, created.ID, created.Mentions); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(notifyThreadSubscribers, eventID, created.UserNickname, created.ForumSlug, created.ThreadID, created.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// NotifyMentions records notifications for users mentioned by an edited post
+func (n *Notification) NotifyMentions(eventID uint64, updated *post.Post) error {
+	_, err := n.conn.Exec(notifyMentions, eventID, updated.UserNickname, updated.ForumSlug, updated.ThreadID, updated.ID, updated.Mentions)
+	return err
+}
+
+func (n *Notification) NotifyThread(eventID uint64, created *thread.Thread) error {
+	_, err := n.conn.Exec(notifyForumSubscribers, eventID, created.UserNickname, created.ForumSlug, created.ID)
+	return err
+}
+
+func (n *Notification) GetNotifications(nickname string, limit *int, since *string, orderDesc bool, unread bool) (*notification.Notifications, error) {
+	if err := n.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	notifications := make(notification.Notifications, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = n.conn.Query(getNotificationsLimitDesc, nickname, limit, unread)
+		} else {
+			rows, err = n.conn.Query(getNotificationsLimit, nickname, limit, unread)
+		}
+	} else {
+		if orderDesc {
+			rows, err = n.conn.Query(getNotificationsLimitSinceDesc, nickname, limit, since, unread)
+		} else {
+			rows, err = n.conn.Query(getNotificationsLimitSince, nickname, limit, since, unread)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row notification.Notification
+		rows.Scan(&row.ID, &row.Kind, &row.Author, &row.ForumSlug, &row.ThreadID, &row.PostID, &row.Read, &row.Created)
+		notifications = append(notifications, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &notifications, nil
+}
+
+func (n *Notification) CountNotifications(nickname string, unread bool) (*page.Total, error) {
+	return countCapped(n.conn, countNotifications, nickname, unread)
+}
+
+// MarkRead marks the given notifications of the user as read, all of them when ids is nil
+func (n *Notification) MarkRead(nickname string, ids []uint64) (*notification.Unread, error) {
+	if err := n.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	var marked []int64
+	if ids != nil {
+		marked = make([]int64, len(ids))
+		for i, id := range ids {
+			marked[i] = int64(id)
+		}
+	}
+
+	if _, err := n.conn.Exec(markNotificationsRead, nickname, marked); err != nil {
+		return nil, err
+	}
+
+	unread := &notification.Unread{}
+	if err := n.conn.QueryRow(countUnreadNotifications, nickname).Scan(&unread.Count); err != nil {
+		return nil, err
+	}
+
+	return unread, nil
+}
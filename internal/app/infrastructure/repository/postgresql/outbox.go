@@ -0,0 +1,152 @@
+package postgresql
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/jackc/pgx"
+	"github.com/mailru/easyjson"
+)
+
+const (
+	createOutboxEvent     = "createOutboxEvent"
+	createOutboxEvents    = "createOutboxEvents"
+	claimOutboxEvents     = "claimOutboxEvents"
+	deleteOutboxEvents    = "deleteOutboxEvents"
+	releaseOutboxEvent    = "releaseOutboxEvent"
+	createOutboxDeadEvent = "createOutboxDeadEvent"
+)
+
+var outboxQueries = map[string]string{
+	createOutboxEvent: `INSERT INTO outbox (type, thread_id, forum_slug, payload)
+	VALUES ($1, $2, $3, $4::TEXT::JSONB);`,
+
+	createOutboxEvents: `INSERT INTO outbox (type, thread_id, forum_slug, payload)
+	SELECT $1, $2, $3, payload::JSONB
+	FROM unnest($4::TEXT[]) WITH ORDINALITY AS batch(payload, position)
+	ORDER BY position;`,
+
+	// Rows claimed by another dispatcher are skipped rather than waited for
+	claimOutboxEvents: `UPDATE outbox
+	SET claimed_until = NOW() + $2::BIGINT * INTERVAL '1 millisecond'
+	WHERE id IN (
+		SELECT id
+		FROM outbox
+		WHERE claimed_until IS NULL OR claimed_until < NOW()
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, type, thread_id, forum_slug, payload::TEXT, handled, attempts;`,
+
+	deleteOutboxEvents: `DELETE FROM outbox
+	WHERE id = ANY($1::BIGINT[]);`,
+
+	releaseOutboxEvent: `UPDATE outbox
+	SET handled = $2, attempts = attempts + 1, claimed_until = NOW() + $3::BIGINT * INTERVAL '1 millisecond'
+	WHERE id = $1
+	RETURNING attempts;`,
+
+	createOutboxDeadEvent: `INSERT INTO outbox_dead_letter (event_id, subscriber, type, thread_id, forum_slug, payload, error)
+	SELECT id, $2, type, thread_id, forum_slug, payload, $3
+	FROM outbox
+	WHERE id = $1;`,
+}
+
+// recordEvent writes an event to the outbox within the transaction making the change
+func recordEvent(tx *pgx.Tx, typed event.Typed) error {
+	payload, err := easyjson.Marshal(typed)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(createOutboxEvent, typed.EventType(), typed.EventThread(), typed.EventForum(), string(payload))
+	return err
+}
+
+// recordEvents writes a batch of events sharing their type and topics in a single statement
+func recordEvents(tx *pgx.Tx, typed []event.Typed) error {
+	if len(typed) == 0 {
+		return nil
+	}
+
+	payloads := make([]string, 0, len(typed))
+	for _, body := range typed {
+		payload, err := easyjson.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payloads = append(payloads, string(payload))
+	}
+
+	_, err := tx.Exec(createOutboxEvents, typed[0].EventType(), typed[0].EventThread(), typed[0].EventForum(), payloads)
+	return err
+}
+
+func NewOutboxRepo(conn *pgx.ConnPool) *Outbox {
+	return &Outbox{
+		conn: conn,
+	}
+}
+
+type Outbox struct {
+	conn *pgx.ConnPool
+}
+
+// ClaimEvents takes up to limit events for the lease, oldest first. Events failed earlier
+// come back once their retry delay is over, a crashed dispatcher leaves them with the lease.
+func (o *Outbox) ClaimEvents(limit int, lease time.Duration) (*event.Events, error) {
+	rows, err := o.conn.Query(claimOutboxEvents, limit, int64(lease/time.Millisecond))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(event.Events, 0)
+	for rows.Next() {
+		var row event.Event
+		var payload string
+		rows.Scan(&row.ID, &row.Type, &row.ThreadID, &row.ForumSlug, &payload, &row.Handled, &row.Attempts)
+		row.Data = []byte(payload)
+		events = append(events, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING keeps no order
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+
+	return &events, nil
+}
+
+// DeleteEvents prunes events every subscriber is done with
+func (o *Outbox) DeleteEvents(ids []uint64) error {
+	deleted := make([]int64, len(ids))
+	for i, id := range ids {
+		deleted[i] = int64(id)
+	}
+
+	_, err := o.conn.Exec(deleteOutboxEvents, deleted)
+	return err
+}
+
+// ReleaseEvent records the subscribers that handled the event and counts a failed round,
+// the event is claimable again after retry
+func (o *Outbox) ReleaseEvent(id uint64, handled []string, retry time.Duration) (int, error) {
+	var attempts int
+	if err := o.conn.QueryRow(releaseOutboxEvent, id, handled, int64(retry/time.Millisecond)).Scan(&attempts); err != nil {
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+// DeadLetter keeps a copy of an event the subscriber gave up on
+func (o *Outbox) DeadLetter(id uint64, subscriber string, reason string) error {
+	_, err := o.conn.Exec(createOutboxDeadEvent, id, subscriber, reason)
+	return err
+}
@@ -6,10 +6,14 @@ import (
 	"github.com/emirpasic/gods/sets/treeset"
 	"github.com/emirpasic/gods/utils"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
@@ -43,6 +47,38 @@ const (
 	getPostsLimitDesc                = "getPostsLimitDesc"
 	getPostsLimitSince               = "getPostsLimitSince"
 	getPostsLimitSinceDesc           = "getPostsLimitSinceDesc"
+	getPostRootScore                 = "getPostRootScore"
+	getPostsTopLimit                 = "getPostsTopLimit"
+	getPostsTopLimitDesc             = "getPostsTopLimitDesc"
+	getPostsTopLimitSince            = "getPostsTopLimitSince"
+	getPostsTopLimitSinceDesc        = "getPostsTopLimitSinceDesc"
+	getPostsTopLimitAfter            = "getPostsTopLimitAfter"
+	getPostsTopLimitAfterDesc        = "getPostsTopLimitAfterDesc"
+	createPostRevision               = "createPostRevision"
+	getPostRevisions                 = "getPostRevisions"
+	getPostRevision                  = "getPostRevision"
+	getPostsByUserLimit              = "getPostsByUserLimit"
+	getPostsByUserLimitDesc          = "getPostsByUserLimitDesc"
+	getPostsByUserLimitSince         = "getPostsByUserLimitSince"
+	getPostsByUserLimitSinceDesc     = "getPostsByUserLimitSinceDesc"
+	countThreadPosts                 = "countThreadPosts"
+	countUserPosts                   = "countUserPosts"
+	createMentions                   = "createMentions"
+	deleteMentions                   = "deleteMentions"
+	getMentionsByUserLimit           = "getMentionsByUserLimit"
+	getMentionsByUserLimitDesc       = "getMentionsByUserLimitDesc"
+	getMentionsByUserLimitSince      = "getMentionsByUserLimitSince"
+	getMentionsByUserLimitSinceDesc  = "getMentionsByUserLimitSinceDesc"
+	countUserMentions                = "countUserMentions"
+	createPostQuarantine             = "createPostQuarantine"
+	getPostQuarantine                = "getPostQuarantine"
+	deletePostQuarantine             = "deletePostQuarantine"
+	hidePost                         = "hidePost"
+	getPostDeletion                  = "getPostDeletion"
+	deletePost                       = "deletePost"
+	deletePostRevisions              = "deletePostRevisions"
+	deletePostVotes                  = "deletePostVotes"
+	deletePostReactions              = "deletePostReactions"
 )
 
 var postQueries = map[string]string{
@@ -50,75 +86,91 @@ var postQueries = map[string]string{
 	FROM post
 	WHERE id = $1 AND thread_id = $2`,
 
-	getPostById: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostById: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE id = $1;`,
 
-	createPost: `INSERT INTO post (message, created, user_nickname, thread_id, forum_slug, parent, parents, root)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	RETURNING id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent`,
-
-	createPostRoot: `INSERT INTO post (message, created, user_nickname, thread_id, forum_slug, parent, parents, root)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT CURRVAL('post_id_seq')))
-	RETURNING id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent`,
+	// Mentioned nicknames are kept only for existing users, in their registered spelling
+	createPost: `INSERT INTO post (message, created, user_nickname, thread_id, forum_slug, parent, parents, root, message_html, mentions)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $10, (
+		SELECT COALESCE(array_agg(nickname::TEXT ORDER BY nickname), '{}')
+		FROM client
+		WHERE nickname = ANY($9::TEXT[]::CITEXT[])
+	))
+	RETURNING id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')`,
+
+	createPostRoot: `INSERT INTO post (message, created, user_nickname, thread_id, forum_slug, parent, parents, root, message_html, mentions)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT CURRVAL('post_id_seq')), $9, (
+		SELECT COALESCE(array_agg(nickname::TEXT ORDER BY nickname), '{}')
+		FROM client
+		WHERE nickname = ANY($8::TEXT[]::CITEXT[])
+	))
+	RETURNING id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')`,
 
 	updatePost: `UPDATE post
 	SET message = COALESCE($1, message),
-	is_edited = TRUE
-	WHERE id = $2;`,
+	is_edited = TRUE,
+	message_html = $4,
+	mentions = (
+		SELECT COALESCE(array_agg(nickname::TEXT ORDER BY nickname), '{}')
+		FROM client
+		WHERE nickname = ANY($3::TEXT[]::CITEXT[])
+	)
+	WHERE id = $2
+	RETURNING mentions;`,
 
 	// Index???
-	getPostsFlat: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsFlat: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1`,
 
-	getPostsFlatLimit: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsFlatLimit: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1
 	ORDER BY id, created
 	LIMIT $2;`,
 
-	getPostsFlatLimitDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsFlatLimitDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1
 	ORDER BY id DESC, created
 	LIMIT $2;`,
 
-	getPostsFlatLimitSince: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsFlatLimitSince: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1  AND id > $3
 	ORDER BY id, created
 	LIMIT $2;`,
 
-	getPostsFlatLimitSinceDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsFlatLimitSinceDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1  AND id < $3
 	ORDER BY id DESC, created
 	LIMIT $2;`,
 
-	getPostsTree: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsTree: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1`,
 
-	getPostsTreeLimit: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsTreeLimit: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1
 	ORDER BY array_append(parents, id)
 	LIMIT $2;`,
 
-	getPostsTreeLimitDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsTreeLimitDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1
 	ORDER BY array_append(parents, id) DESC
 	LIMIT $2;`,
 
-	getPostsTreeLimitSince: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsTreeLimitSince: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1 AND array_append(parents, id) > $3
 	ORDER BY array_append(parents, id)
 	LIMIT $2;`,
 
-	getPostsTreeLimitSinceDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsTreeLimitSinceDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1 AND array_append(parents, id) < $3
 	ORDER BY array_append(parents, id) DESC
@@ -132,7 +184,7 @@ var postQueries = map[string]string{
 	FROM post
 	WHERE id = $1;`,
 
-	getPostsParentTreeLimit: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsParentTreeLimit: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
 	FROM post AS p
   	JOIN (
       SELECT id
@@ -144,7 +196,7 @@ var postQueries = map[string]string{
 	) AS s ON (p.root = s.id)
 	ORDER BY root, array_append(p.parents, p.id)`,
 
-	getPostsParentTreeLimitDesc: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsParentTreeLimitDesc: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
 	FROM post AS p
   	JOIN (
       SELECT id
@@ -156,7 +208,7 @@ var postQueries = map[string]string{
 	) AS s ON (p.root = s.id)
 	ORDER BY root DESC, array_append(p.parents, p.id)`,
 
-	getPostsParentTreeLimitSince: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsParentTreeLimitSince: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
 	FROM post AS p
   	JOIN (
       SELECT id
@@ -169,7 +221,7 @@ var postQueries = map[string]string{
 	) AS s ON (p.root = s.id)
 	ORDER BY root, array_append(p.parents, p.id)`,
 
-	getPostsParentTreeLimitSinceDesc: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsParentTreeLimitSinceDesc: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
 	FROM post AS p
   	JOIN (
       SELECT id
@@ -182,29 +234,237 @@ var postQueries = map[string]string{
 	) AS s ON (p.root = s.id)
 	ORDER BY root DESC, array_append(p.parents, p.id)`,
 
-	getPostsLimit: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostRootScore: `SELECT r.id, r.votes
+	FROM post AS p
+	JOIN post AS r ON (r.id = p.root)
+	WHERE p.id = $1;`,
+
+	getPostsTopLimit: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM post AS p
+	JOIN (
+		SELECT id, votes AS score
+		FROM post
+		WHERE parent = 0
+			AND thread_id = $1
+		ORDER BY votes DESC, id
+		LIMIT $2
+	) AS s ON (p.root = s.id)
+	ORDER BY s.score DESC, root, array_append(p.parents, p.id)`,
+
+	getPostsTopLimitDesc: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM post AS p
+	JOIN (
+		SELECT id, votes AS score
+		FROM post
+		WHERE parent = 0
+			AND thread_id = $1
+		ORDER BY votes, id DESC
+		LIMIT $2
+	) AS s ON (p.root = s.id)
+	ORDER BY s.score, root DESC, array_append(p.parents, p.id)`,
+
+	getPostsTopLimitSince: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM post AS p
+	JOIN (
+		SELECT id, votes AS score
+		FROM post
+		WHERE parent = 0
+			AND thread_id = $1
+			AND (votes < $4 OR (votes = $4 AND id > $3))
+		ORDER BY votes DESC, id
+		LIMIT $2
+	) AS s ON (p.root = s.id)
+	ORDER BY s.score DESC, root, array_append(p.parents, p.id)`,
+
+	getPostsTopLimitSinceDesc: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM post AS p
+	JOIN (
+		SELECT id, votes AS score
+		FROM post
+		WHERE parent = 0
+			AND thread_id = $1
+			AND (votes > $4 OR (votes = $4 AND id < $3))
+		ORDER BY votes, id DESC
+		LIMIT $2
+	) AS s ON (p.root = s.id)
+	ORDER BY s.score, root DESC, array_append(p.parents, p.id)`,
+
+	getPostsTopLimitAfter: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM post AS p
+	JOIN (
+		SELECT id, votes AS score
+		FROM post
+		WHERE parent = 0
+			AND thread_id = $1
+			AND (votes < $3::TEXT::INTEGER OR (votes = $3::TEXT::INTEGER AND id > $4))
+		ORDER BY votes DESC, id
+		LIMIT $2
+	) AS s ON (p.root = s.id)
+	ORDER BY s.score DESC, root, array_append(p.parents, p.id)`,
+
+	getPostsTopLimitAfterDesc: `SELECT p.id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM post AS p
+	JOIN (
+		SELECT id, votes AS score
+		FROM post
+		WHERE parent = 0
+			AND thread_id = $1
+			AND (votes > $3::TEXT::INTEGER OR (votes = $3::TEXT::INTEGER AND id < $4))
+		ORDER BY votes, id DESC
+		LIMIT $2
+	) AS s ON (p.root = s.id)
+	ORDER BY s.score, root DESC, array_append(p.parents, p.id)`,
+
+	createPostRevision: `INSERT INTO post_revision (post_id, message, editor)
+	VALUES ($1, $2, $3);`,
+
+	getPostRevisions: `SELECT id, post_id, message, editor, created
+	FROM post_revision
+	WHERE post_id = $1
+	ORDER BY id;`,
+
+	getPostRevision: `SELECT id, post_id, message, editor, created
+	FROM post_revision
+	WHERE id = $1 AND post_id = $2;`,
+
+	getPostsByUserLimit: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
+	FROM post
+	WHERE user_nickname = $1
+	ORDER BY id
+	LIMIT $2`,
+
+	getPostsByUserLimitDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
+	FROM post
+	WHERE user_nickname = $1
+	ORDER BY id DESC
+	LIMIT $2`,
+
+	getPostsByUserLimitSince: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
+	FROM post
+	WHERE user_nickname = $1 AND id > $3
+	ORDER BY id
+	LIMIT $2`,
+
+	getPostsByUserLimitSinceDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
+	FROM post
+	WHERE user_nickname = $1 AND id < $3
+	ORDER BY id DESC
+	LIMIT $2`,
+
+	getPostsLimit: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1
 	ORDER BY id
 	LIMIT $2`,
 
-	getPostsLimitDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsLimitDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1
 	ORDER BY id DESC
 	LIMIT $2`,
 
-	getPostsLimitSince: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsLimitSince: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1 AND id > $3
 	ORDER BY id
 	LIMIT $2`,
 
-	getPostsLimitSinceDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent
+	getPostsLimitSinceDesc: `SELECT id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')
 	FROM post
 	WHERE thread_id = $1 AND id < $3
 	ORDER BY id DESC
 	LIMIT $2`,
+
+	countThreadPosts: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM post WHERE thread_id = $1 LIMIT $2) AS capped;`,
+
+	countUserPosts: `SELECT posts
+	FROM client
+	WHERE nickname = $1;`,
+
+	createMentions: `INSERT INTO mention (post_id, user_nickname)
+	SELECT $1, unnest($2::TEXT[])
+	ON CONFLICT DO NOTHING;`,
+
+	deleteMentions: `DELETE FROM mention
+	WHERE post_id = $1;`,
+
+	getMentionsByUserLimit: `SELECT p.id, p.message, p.created, p.is_edited, p.user_nickname, p.thread_id, p.forum_slug, p.parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM mention AS m
+	JOIN post AS p ON (p.id = m.post_id)
+	WHERE m.user_nickname = $1
+	ORDER BY m.post_id
+	LIMIT $2`,
+
+	getMentionsByUserLimitDesc: `SELECT p.id, p.message, p.created, p.is_edited, p.user_nickname, p.thread_id, p.forum_slug, p.parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM mention AS m
+	JOIN post AS p ON (p.id = m.post_id)
+	WHERE m.user_nickname = $1
+	ORDER BY m.post_id DESC
+	LIMIT $2`,
+
+	getMentionsByUserLimitSince: `SELECT p.id, p.message, p.created, p.is_edited, p.user_nickname, p.thread_id, p.forum_slug, p.parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM mention AS m
+	JOIN post AS p ON (p.id = m.post_id)
+	WHERE m.user_nickname = $1 AND m.post_id > $3::TEXT::INTEGER
+	ORDER BY m.post_id
+	LIMIT $2`,
+
+	getMentionsByUserLimitSinceDesc: `SELECT p.id, p.message, p.created, p.is_edited, p.user_nickname, p.thread_id, p.forum_slug, p.parent, p.votes, p.reactions, p.mentions, COALESCE(p.message_html, '')
+	FROM mention AS m
+	JOIN post AS p ON (p.id = m.post_id)
+	WHERE m.user_nickname = $1 AND m.post_id < $3::TEXT::INTEGER
+	ORDER BY m.post_id DESC
+	LIMIT $2`,
+
+	countUserMentions: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM mention WHERE user_nickname = $1 LIMIT $2) AS capped;`,
+
+	// Every quarantined post opens an item in the moderation queue of its forum
+	createPostQuarantine: `WITH held AS (
+		INSERT INTO post_quarantine (thread_id, forum_slug, user_nickname, message, parent, reason)
+		SELECT $1, $2, nickname, $4, $5, $6
+		FROM client
+		WHERE nickname = $3
+		RETURNING id, thread_id, forum_slug, user_nickname, message, reason
+	)
+	INSERT INTO moderation_item (forum_slug, kind, target_id, thread_id, user_nickname, message, reasons)
+	SELECT forum_slug, 'quarantine', id, thread_id, user_nickname, message, ARRAY[reason]
+	FROM held
+	RETURNING target_id;`,
+
+	getPostQuarantine: `SELECT thread_id, user_nickname, message, parent
+	FROM post_quarantine
+	WHERE id = $1;`,
+
+	deletePostQuarantine: `DELETE FROM post_quarantine
+	WHERE id = $1
+	RETURNING id;`,
+
+	hidePost: `UPDATE post
+	SET message = '', message_html = '', mentions = '{}'
+	WHERE id = $1
+	RETURNING id;`,
+
+	getPostDeletion: `SELECT forum_slug, user_nickname, votes, EXISTS(
+		SELECT 1
+		FROM post AS reply
+		WHERE reply.root = post.root AND reply.parent = post.id
+	)
+	FROM post
+	WHERE id = $1;`,
+
+	deletePost: `DELETE FROM post
+	WHERE id = $1;`,
+
+	deletePostRevisions: `DELETE FROM post_revision
+	WHERE post_id = $1;`,
+
+	deletePostVotes: `DELETE FROM post_vote
+	WHERE post_id = $1;`,
+
+	deletePostReactions: `DELETE FROM post_reaction
+	WHERE post_id = $1;`,
 }
 
 var (
@@ -229,7 +489,7 @@ func (p *Post) GetPost(id string, related map[string]bool) (*post.Info, error) {
 	var info post.Info
 
 	var post post.Post
-	if err := p.conn.QueryRow(getPostById, id).Scan(&post.ID, &post.Message, &post.Created, &post.IsEdited, &post.UserNickname, &post.ThreadID, &post.ForumSlug, &post.Parent); err != nil {
+	if err := p.conn.QueryRow(getPostById, id).Scan(&post.ID, &post.Message, &post.Created, &post.IsEdited, &post.UserNickname, &post.ThreadID, &post.ForumSlug, &post.Parent, &post.Votes, &post.Reactions, &post.Mentions, &post.Rendered); err != nil {
 		return nil, err
 	}
 	info.Post = post
@@ -246,7 +506,7 @@ func (p *Post) GetPost(id string, related map[string]bool) (*post.Info, error) {
 	if value, exists := related["forum"]; value && exists {
 		var relatedForum forum.Forum
 		if err := p.conn.QueryRow(getForumBySlug, post.ForumSlug).
-			Scan(&relatedForum.Slug, &relatedForum.Title, &relatedForum.Posts, &relatedForum.Threads, &relatedForum.UserNickname); err != nil {
+			Scan(&relatedForum.Slug, &relatedForum.Title, &relatedForum.Posts, &relatedForum.Threads, &relatedForum.UserNickname, &relatedForum.Parent, &relatedForum.Category); err != nil {
 			return nil, err
 		}
 		info.Forum = &relatedForum
@@ -255,7 +515,7 @@ func (p *Post) GetPost(id string, related map[string]bool) (*post.Info, error) {
 	if value, exists := related["thread"]; value && exists {
 		var relatedThread thread.Thread
 		if err := p.conn.QueryRow(getThreadById, post.ThreadID).
-			Scan(&relatedThread.ID, &relatedThread.Slug, &relatedThread.Title, &relatedThread.Message, &relatedThread.ForumSlug, &relatedThread.UserNickname, &relatedThread.Created, &relatedThread.Votes); err != nil {
+			Scan(&relatedThread.ID, &relatedThread.Slug, &relatedThread.Title, &relatedThread.Message, &relatedThread.ForumSlug, &relatedThread.UserNickname, &relatedThread.Created, &relatedThread.Votes, &relatedThread.Tags, &relatedThread.Rendered); err != nil {
 			return nil, err
 		}
 		info.Thread = &relatedThread
@@ -264,7 +524,7 @@ func (p *Post) GetPost(id string, related map[string]bool) (*post.Info, error) {
 	return &info, nil
 }
 
-func (p *Post) UpdatePost(data *post.Update) (*post.Post, error) {
+func (p *Post) UpdatePost(data *post.Update, emit func(*post.Post) event.Typed) (*post.Post, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -273,7 +533,7 @@ func (p *Post) UpdatePost(data *post.Update) (*post.Post, error) {
 
 	var received post.Post
 	if err := tx.QueryRow(getPostById, data.ID).
-		Scan(&received.ID, &received.Message, &received.Created, &received.IsEdited, &received.UserNickname, &received.ThreadID, &received.ForumSlug, &received.Parent); err != nil {
+		Scan(&received.ID, &received.Message, &received.Created, &received.IsEdited, &received.UserNickname, &received.ThreadID, &received.ForumSlug, &received.Parent, &received.Votes, &received.Reactions, &received.Mentions, &received.Rendered); err != nil {
 		return nil, err
 	}
 
@@ -281,16 +541,75 @@ func (p *Post) UpdatePost(data *post.Update) (*post.Post, error) {
 		return &received, nil
 	}
 
-	if _, err := tx.Exec(updatePost, data.Message, data.ID); err != nil {
+	// Edits made without an explicit editor are attributed to the author
+	editor := received.UserNickname
+	if data.Editor != nil {
+		if err := tx.QueryRow(getUserByNickname, data.Editor).Scan(&editor); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(createPostRevision, received.ID, received.Message, editor); err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(updatePost, data.Message, data.ID, data.Mentions, data.Rendered).Scan(&received.Mentions); err != nil {
 		return nil, err
 	}
 
+	if _, err := tx.Exec(deleteMentions, received.ID); err != nil {
+		return nil, err
+	}
+
+	if len(received.Mentions) != 0 {
+		if _, err := tx.Exec(createMentions, received.ID, received.Mentions); err != nil {
+			return nil, err
+		}
+	}
+
 	received.Message = *data.Message
+	received.Rendered = *data.Rendered
 	received.IsEdited = true
+
+	if err := recordEvent(tx, emit(&received)); err != nil {
+		return nil, err
+	}
+
 	tx.Commit()
 	return &received, nil
 }
 
+func (p *Post) GetPostHistory(id string) (*post.Revisions, error) {
+	var postID uint64
+	if err := p.conn.QueryRow(checkPostById, id).Scan(&postID); err != nil {
+		return nil, err
+	}
+
+	rows, err := p.conn.Query(getPostRevisions, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make(post.Revisions, 0)
+	for rows.Next() {
+		var row post.Revision
+		rows.Scan(&row.ID, &row.PostID, &row.Message, &row.Editor, &row.Created)
+		revisions = append(revisions, row)
+	}
+
+	return &revisions, nil
+}
+
+func (p *Post) GetPostRevision(id string, revisionID uint64) (*post.Revision, error) {
+	var revision post.Revision
+	if err := p.conn.QueryRow(getPostRevision, revisionID, id).
+		Scan(&revision.ID, &revision.PostID, &revision.Message, &revision.Editor, &revision.Created); err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
 func getUsersBatch(tx *pgx.Tx, data *post.PostsCreate) (*map[string]user.Info, error) {
 	batch := tx.BeginBatch()
 	defer batch.Close()
@@ -356,11 +675,11 @@ func createPostsBatch(tx *pgx.Tx, data *post.PostsCreate, threadID uint64, forum
 	for _, newPost := range *data {
 		if newPost.Parent == 0 {
 			batch.Queue(createPostRoot,
-				[]interface{}{newPost.Message, createTime, newPost.UserNickname, threadID, forumSlug, newPost.Parent, []int32{}},
+				[]interface{}{newPost.Message, createTime, newPost.UserNickname, threadID, forumSlug, newPost.Parent, []int32{}, newPost.Mentions, newPost.Rendered},
 				nil, nil)
 		} else {
 			batch.Queue(createPost,
-				[]interface{}{newPost.Message, createTime, newPost.UserNickname, threadID, forumSlug, newPost.Parent, newPost.Parents, newPost.Root},
+				[]interface{}{newPost.Message, createTime, newPost.UserNickname, threadID, forumSlug, newPost.Parent, newPost.Parents, newPost.Root, newPost.Mentions, newPost.Rendered},
 				nil, nil)
 		}
 	}
@@ -373,7 +692,7 @@ func createPostsBatch(tx *pgx.Tx, data *post.PostsCreate, threadID uint64, forum
 	for range *data {
 		var created post.Post
 		if err := batch.QueryRowResults().
-			Scan(&created.ID, &created.Message, &created.Created, &created.IsEdited, &created.UserNickname, &created.ThreadID, &created.ForumSlug, &created.Parent); err != nil {
+			Scan(&created.ID, &created.Message, &created.Created, &created.IsEdited, &created.UserNickname, &created.ThreadID, &created.ForumSlug, &created.Parent, &created.Votes, &created.Reactions, &created.Mentions, &created.Rendered); err != nil {
 			return nil, err
 		}
 		posts = append(posts, created)
@@ -382,6 +701,61 @@ func createPostsBatch(tx *pgx.Tx, data *post.PostsCreate, threadID uint64, forum
 	return &posts, nil
 }
 
+func createMentionsBatch(tx *pgx.Tx, posts *post.Posts) error {
+	batch := tx.BeginBatch()
+	defer batch.Close()
+
+	queued := 0
+	for _, created := range *posts {
+		if len(created.Mentions) != 0 {
+			batch.Queue(createMentions, []interface{}{created.ID, created.Mentions}, nil, nil)
+			queued++
+		}
+	}
+
+	if queued == 0 {
+		return nil
+	}
+
+	if err := batch.Send(context.Background(), nil); err != nil {
+		return err
+	}
+
+	for i := 0; i < queued; i++ {
+		if _, err := batch.ExecResults(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updateUsersPostsBatch(tx *pgx.Tx, data *post.PostsCreate, users *map[string]user.Info) error {
+	batch := tx.BeginBatch()
+	defer batch.Close()
+
+	counts := make(map[string]int, len(*users))
+	for _, newPost := range *data {
+		counts[strings.ToLower(newPost.UserNickname)]++
+	}
+
+	for nickname, count := range counts {
+		batch.Queue(updateUserPosts, []interface{}{count, (*users)[nickname].Nickname}, nil, nil)
+	}
+
+	if err := batch.Send(context.Background(), nil); err != nil {
+		return err
+	}
+
+	for range counts {
+		if _, err := batch.ExecResults(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func createForumUsers(conn *pgx.ConnPool, forumSlug string, users *map[string]user.Info) error {
 	for _, info := range *users {
 		if _, err := conn.Exec(createForumUser, forumSlug, info.Email, info.Nickname, info.Fullname, info.About); err != nil {
@@ -392,7 +766,18 @@ func createForumUsers(conn *pgx.ConnPool, forumSlug string, users *map[string]us
 	return nil
 }
 
-func (p *Post) CreatePosts(data *post.PostsCreate, slugOrId string) (*post.Posts, error) {
+func recordPostsCreated(tx *pgx.Tx, posts *post.Posts, emit func(*post.Post) event.Typed) error {
+	typed := make([]event.Typed, 0, len(*posts))
+	for i := range *posts {
+		typed = append(typed, emit(&(*posts)[i]))
+	}
+
+	return recordEvents(tx, typed)
+}
+
+// CreatePosts publishes data and holds back the quarantined posts in one transaction,
+// both sets are checked for existing authors and parents in the thread
+func (p *Post) CreatePosts(data *post.PostsCreate, held *post.PostsCreate, slugOrId string, emit func(*post.Post) event.Typed) (*post.Posts, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
 		log.Println("[Failed] creating transaction. Error:", err)
@@ -408,46 +793,97 @@ func (p *Post) CreatePosts(data *post.PostsCreate, slugOrId string) (*post.Posts
 		return nil, err
 	}
 
+	if held != nil && len(*held) != 0 {
+		if _, err := getUsersBatch(tx, held); err != nil {
+			log.Println("[Failed] getting held posts users. Error:", err)
+			return nil, err
+		}
+
+		if err := getPostParentsBatch(tx, held, threadID); err != nil {
+			log.Println("[Failed] getting held posts parents. Error:", err)
+			return nil, err
+		}
+
+		if err := quarantinePosts(tx, held, threadID, forumSlug); err != nil {
+			log.Println("[Failed] quarantining posts. Error:", err)
+			return nil, err
+		}
+	}
+
+	posts, users, err := createPosts(tx, data, threadID, forumSlug, emit)
+	if err != nil {
+		return nil, err
+	}
+	tx.Commit()
+
+	if err := createdPosts(p.conn, forumSlug, users, len(*data)); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// createPosts writes a batch into its thread together with mentions, counters and events
+func createPosts(tx *pgx.Tx, data *post.PostsCreate, threadID uint64, forumSlug string, emit func(*post.Post) event.Typed) (*post.Posts, *map[string]user.Info, error) {
 	users, err := getUsersBatch(tx, data)
 	if err != nil {
 		log.Println("[Failed] getting users using batch. Error:", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := getPostParentsBatch(tx, data, threadID); err != nil {
 		log.Println("[Failed] getting posts parents. Error:", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	posts, err := createPostsBatch(tx, data, threadID, forumSlug)
 	if err != nil {
 		log.Println("[Failed] creating posts. Error:", err)
-		return nil, err
+		return nil, nil, err
+	}
+
+	if err := createMentionsBatch(tx, posts); err != nil {
+		log.Println("[Failed] creating mentions. Error:", err)
+		return nil, nil, err
 	}
 
 	if _, err := tx.Exec(updateForumPosts, len(*data), forumSlug); err != nil {
 		log.Println("[Failed] updating forum posts. Error:", err)
-		return nil, err
+		return nil, nil, err
 	}
-	tx.Commit()
 
-	if err := createForumUsers(p.conn, forumSlug, users); err != nil {
+	if err := updateUsersPostsBatch(tx, data, users); err != nil {
+		log.Println("[Failed] updating users posts. Error:", err)
+		return nil, nil, err
+	}
+
+	if err := recordPostsCreated(tx, posts, emit); err != nil {
+		log.Println("[Failed] recording created posts. Error:", err)
+		return nil, nil, err
+	}
+
+	return posts, users, nil
+}
+
+// createdPosts runs the work following a committed batch, the forum users and periodic clustering
+func createdPosts(conn *pgx.ConnPool, forumSlug string, users *map[string]user.Info, count int) error {
+	if err := createForumUsers(conn, forumSlug, users); err != nil {
 		log.Println("[Failed] creating forum users. Error:", err)
-		return nil, err
+		return err
 	}
 
-	CurrentPostNumber += len(*data)
+	CurrentPostNumber += count
 	if CurrentPostNumber >= ClusteringStep {
-		if err := ExecFromFile(p.conn, "build/schema/1_cluster.sql"); err != nil {
+		if err := ExecFromFile(conn, "build/schema/1_cluster.sql"); err != nil {
 			log.Fatal("[Failed] creating clusters. Error:", err)
 		}
 
-		if _, err := p.conn.Exec("VACUUM ANALYZE;"); err != nil {
+		if _, err := conn.Exec("VACUUM ANALYZE;"); err != nil {
 			log.Fatal("[Failed] vacuum analyze. Error:", err)
 		}
 	}
 
-	return posts, nil
+	return nil
 }
 
 func (p *Post) GetPostsFlat(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
@@ -481,7 +917,7 @@ func (p *Post) GetPostsFlat(slugOrId string, limit *int, since *string, orderDes
 
 	for rows.Next() {
 		var row post.Post
-		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent)
+		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent, &row.Votes, &row.Reactions, &row.Mentions, &row.Rendered)
 		posts = append(posts, row)
 	}
 
@@ -521,20 +957,24 @@ func (p *Post) GetPostsTree(slugOrId string, limit *int, since *string, orderDes
 
 	for rows.Next() {
 		var row post.Post
-		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent)
+		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent, &row.Votes, &row.Reactions, &row.Mentions, &row.Rendered)
 		posts = append(posts, row)
 	}
 
 	return &posts, nil
 }
 
-func (p *Post) GetPostsParentTree(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
+func (p *Post) GetPostsParentTree(slugOrId string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*post.Posts, error) {
 	var threadID uint64
 	if err := p.conn.QueryRow(getThreadShortBySlugOrId, slugOrId).
 		Scan(&threadID, &slugOrId); err != nil {
 		return nil, err
 	}
 
+	if after != nil {
+		since = &after.Key
+	}
+
 	posts := make(post.Posts, 0)
 	var err error
 	var rows *pgx.Rows
@@ -546,7 +986,10 @@ func (p *Post) GetPostsParentTree(slugOrId string, limit *int, since *string, or
 			rows, err = p.conn.Query(getPostsParentTreeLimit, threadID, limit)
 		}
 	} else {
-		_ = p.conn.QueryRow(getPostRoot, since).Scan(&since)
+		// Cursors already carry the root id, legacy since values are resolved to their root
+		if after == nil {
+			_ = p.conn.QueryRow(getPostRoot, since).Scan(&since)
+		}
 		if orderDesc {
 			rows, err = p.conn.Query(getPostsParentTreeLimitSinceDesc, threadID, limit, since)
 		} else {
@@ -560,7 +1003,7 @@ func (p *Post) GetPostsParentTree(slugOrId string, limit *int, since *string, or
 
 	for rows.Next() {
 		var row post.Post
-		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent)
+		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent, &row.Votes, &row.Reactions, &row.Mentions, &row.Rendered)
 		posts = append(posts, row)
 	}
 
@@ -598,9 +1041,245 @@ func (p *Post) GetPosts(slugOrId string, limit *int, since *string, orderDesc bo
 
 	for rows.Next() {
 		var row post.Post
-		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent)
+		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent, &row.Votes, &row.Reactions, &row.Mentions, &row.Rendered)
+		posts = append(posts, row)
+	}
+
+	return &posts, nil
+}
+
+func (p *Post) GetPostsTop(slugOrId string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*post.Posts, error) {
+	var threadID uint64
+	if err := p.conn.QueryRow(getThreadShortBySlugOrId, slugOrId).
+		Scan(&threadID, &slugOrId); err != nil {
+		return nil, err
+	}
+
+	posts := make(post.Posts, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if after != nil {
+		if orderDesc {
+			rows, err = p.conn.Query(getPostsTopLimitAfterDesc, threadID, limit, after.Key, after.ID)
+		} else {
+			rows, err = p.conn.Query(getPostsTopLimitAfter, threadID, limit, after.Key, after.ID)
+		}
+	} else if since == nil {
+		if orderDesc {
+			rows, err = p.conn.Query(getPostsTopLimitDesc, threadID, limit)
+		} else {
+			rows, err = p.conn.Query(getPostsTopLimit, threadID, limit)
+		}
+	} else {
+		// Top-level posts are ordered by score, so continue after the root of the since post
+		var rootID, rootScore int
+		_ = p.conn.QueryRow(getPostRootScore, since).Scan(&rootID, &rootScore)
+		if orderDesc {
+			rows, err = p.conn.Query(getPostsTopLimitSinceDesc, threadID, limit, rootID, rootScore)
+		} else {
+			rows, err = p.conn.Query(getPostsTopLimitSince, threadID, limit, rootID, rootScore)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row post.Post
+		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent, &row.Votes, &row.Reactions, &row.Mentions, &row.Rendered)
 		posts = append(posts, row)
 	}
 
 	return &posts, nil
 }
+
+func (p *Post) GetUserPosts(nickname string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
+	if err := p.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	posts := make(post.Posts, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = p.conn.Query(getPostsByUserLimitDesc, nickname, limit)
+		} else {
+			rows, err = p.conn.Query(getPostsByUserLimit, nickname, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = p.conn.Query(getPostsByUserLimitSinceDesc, nickname, limit, since)
+		} else {
+			rows, err = p.conn.Query(getPostsByUserLimitSince, nickname, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row post.Post
+		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent, &row.Votes, &row.Reactions, &row.Mentions, &row.Rendered)
+		posts = append(posts, row)
+	}
+
+	return &posts, nil
+}
+
+func (p *Post) GetUserMentions(nickname string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
+	if err := p.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	posts := make(post.Posts, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = p.conn.Query(getMentionsByUserLimitDesc, nickname, limit)
+		} else {
+			rows, err = p.conn.Query(getMentionsByUserLimit, nickname, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = p.conn.Query(getMentionsByUserLimitSinceDesc, nickname, limit, since)
+		} else {
+			rows, err = p.conn.Query(getMentionsByUserLimitSince, nickname, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row post.Post
+		rows.Scan(&row.ID, &row.Message, &row.Created, &row.IsEdited, &row.UserNickname, &row.ThreadID, &row.ForumSlug, &row.Parent, &row.Votes, &row.Reactions, &row.Mentions, &row.Rendered)
+		posts = append(posts, row)
+	}
+
+	return &posts, nil
+}
+
+func (p *Post) CountPosts(slugOrId string) (*page.Total, error) {
+	var threadID uint64
+	if err := p.conn.QueryRow(getThreadShortBySlugOrId, slugOrId).
+		Scan(&threadID, &slugOrId); err != nil {
+		return nil, err
+	}
+
+	return countCapped(p.conn, countThreadPosts, threadID)
+}
+
+func (p *Post) CountUserPosts(nickname string) (*page.Total, error) {
+	return countExact(p.conn, countUserPosts, nickname)
+}
+
+func (p *Post) CountUserMentions(nickname string) (*page.Total, error) {
+	return countCapped(p.conn, countUserMentions, nickname)
+}
+
+// quarantinePosts holds posts back from the thread together with the reason they were flagged
+func quarantinePosts(tx *pgx.Tx, held *post.PostsCreate, threadID uint64, forumSlug string) error {
+	for _, created := range *held {
+		var id uint64
+		if err := tx.QueryRow(createPostQuarantine, threadID, forumSlug, created.UserNickname, created.Message,
+			created.Parent, created.Quarantine).Scan(&id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetQuarantined returns a held post as a creation payload together with its thread
+func (p *Post) GetQuarantined(id uint64) (*post.Create, uint64, error) {
+	var held post.Create
+	var threadID uint64
+
+	if err := p.conn.QueryRow(getPostQuarantine, id).
+		Scan(&threadID, &held.UserNickname, &held.Message, &held.Parent); err != nil {
+		return nil, 0, err
+	}
+
+	return &held, threadID, nil
+}
+
+// releaseQuarantined moves a held post into its thread
+func releaseQuarantined(tx *pgx.Tx, id uint64, threadID uint64, held *post.Create, emit func(*post.Post) event.Typed) (*post.Posts, string, *map[string]user.Info, error) {
+	var forumSlug string
+
+	if err := tx.QueryRow(deletePostQuarantine, id).Scan(&id); err != nil {
+		return nil, "", nil, err
+	}
+
+	if err := tx.QueryRow(getThreadShortBySlugOrId, strconv.FormatUint(threadID, 10)).Scan(&threadID, &forumSlug); err != nil {
+		return nil, "", nil, err
+	}
+
+	posts, users, err := createPosts(tx, &post.PostsCreate{*held}, threadID, forumSlug, emit)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return posts, forumSlug, users, nil
+}
+
+// hideModeratedPost blanks the message of a post and drops its revisions and mentions, the post keeps its place in the tree
+func hideModeratedPost(tx *pgx.Tx, id uint64) error {
+	if err := tx.QueryRow(hidePost, id).Scan(&id); err != nil {
+		return err
+	}
+
+	for _, query := range []string{deletePostRevisions, deleteMentions} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteModeratedPost removes a post that has no replies, posts with replies can only be hidden.
+// The votes on the post are taken back from the author reputation.
+func deleteModeratedPost(tx *pgx.Tx, id uint64) error {
+	var forumSlug, nickname string
+	var votes int
+	var replied bool
+
+	if err := tx.QueryRow(getPostDeletion, id).Scan(&forumSlug, &nickname, &votes, &replied); err != nil {
+		return err
+	}
+
+	if replied {
+		return errors.New("postHasReplies")
+	}
+
+	for _, query := range []string{deletePost, deletePostRevisions, deletePostVotes, deletePostReactions, deleteMentions, deletePostBookmarks} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(updateForumPosts, -1, forumSlug); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(updateUserPosts, -1, nickname); err != nil {
+		return err
+	}
+
+	if votes != 0 {
+		if err := changeReputation(tx, -votes, nickname, forumSlug); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
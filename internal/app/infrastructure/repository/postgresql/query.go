@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/jackc/pgx"
 	"io/ioutil"
 )
@@ -27,3 +28,32 @@ func ExecFromFile(conn *pgx.ConnPool, path string) error {
 
 	return nil
 }
+
+// countCap bounds the rows counted for list totals, larger lists report it as a lower bound
+const countCap = 10000
+
+func countCapped(conn *pgx.ConnPool, query string, args ...interface{}) (*page.Total, error) {
+	var count int64
+	if err := conn.QueryRow(query, append(args, countCap+1)...).Scan(&count); err != nil {
+		return nil, err
+	}
+
+	if count > countCap {
+		return &page.Total{Count: countCap, AtLeast: true}, nil
+	}
+	return &page.Total{Count: count}, nil
+}
+
+func countExact(conn *pgx.ConnPool, query string, args ...interface{}) (*page.Total, error) {
+	total := &page.Total{}
+	if err := conn.QueryRow(query, args...).Scan(&total.Count); err != nil {
+		return nil, err
+	}
+
+	return total, nil
+}
+
+// rowScanner is satisfied by both *pgx.Row and *pgx.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
@@ -0,0 +1,113 @@
+package postgresql
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/search"
+	"github.com/jackc/pgx"
+)
+
+const (
+	searchContent = "searchContent"
+)
+
+// Documents are escaped before highlighting so snippets only contain markup produced by ts_headline
+var searchQueries = map[string]string{
+	searchContent: `SELECT type, id, thread_id, forum_slug, user_nickname, created, title,
+		ts_headline('simple',
+			replace(replace(replace(document, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+			websearch_to_tsquery('simple', $1),
+			'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15'),
+		rank
+	FROM (
+		SELECT type, id, thread_id, forum_slug, user_nickname, created, title, document, rank
+		FROM (
+			SELECT 'post' AS type, id, thread_id, forum_slug, user_nickname, created, '' AS title,
+				message AS document,
+				ts_rank(to_tsvector('simple', message), query) AS rank
+			FROM post, websearch_to_tsquery('simple', $1) AS query
+			WHERE to_tsvector('simple', message) @@ query
+				AND ($2::CITEXT IS NULL OR forum_slug = $2::CITEXT)
+				AND ($3::INTEGER IS NULL OR thread_id = $3::INTEGER)
+				AND ($4::CITEXT IS NULL OR user_nickname = $4::CITEXT)
+				AND ($5::TIMESTAMPTZ IS NULL OR created >= $5::TIMESTAMPTZ)
+				AND ($6::TIMESTAMPTZ IS NULL OR created < $6::TIMESTAMPTZ)
+			UNION ALL
+			SELECT 'thread' AS type, id, id AS thread_id, forum_slug, user_nickname, created, title,
+				title || ' ' || COALESCE(message, '') AS document,
+				ts_rank(setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', COALESCE(message, '')), 'B'), query) AS rank
+			FROM thread, websearch_to_tsquery('simple', $1) AS query
+			WHERE setweight(to_tsvector('simple', title), 'A') || setweight(to_tsvector('simple', COALESCE(message, '')), 'B') @@ query
+				AND ($2::CITEXT IS NULL OR forum_slug = $2::CITEXT)
+				AND ($3::INTEGER IS NULL OR id = $3::INTEGER)
+				AND ($4::CITEXT IS NULL OR user_nickname = $4::CITEXT)
+				AND ($5::TIMESTAMPTZ IS NULL OR created >= $5::TIMESTAMPTZ)
+				AND ($6::TIMESTAMPTZ IS NULL OR created < $6::TIMESTAMPTZ)
+		) AS matches
+		WHERE $7::REAL IS NULL
+			OR rank < $7::REAL
+			OR (rank = $7::REAL AND (type > $8::TEXT OR (type = $8::TEXT AND id > $9::INTEGER)))
+		ORDER BY rank DESC, type, id
+		LIMIT $10
+	) AS page
+	ORDER BY rank DESC, type, id;`,
+}
+
+func NewSearchRepo(conn *pgx.ConnPool) *Search {
+	return &Search{
+		conn: conn,
+	}
+}
+
+type Search struct {
+	conn *pgx.ConnPool
+}
+
+func (s *Search) Search(query *search.Query) (*search.Results, error) {
+	var threadID *uint64
+	if query.Thread != nil {
+		var id uint64
+		var slug *string
+		if err := s.conn.QueryRow(checkThreadByIdOrSlug, query.Thread).
+			Scan(&id, &slug); err != nil {
+			return nil, err
+		}
+		threadID = &id
+	}
+
+	var afterRank *float32
+	var afterType *string
+	var afterID *uint64
+	if query.After != nil {
+		rank, err := strconv.ParseFloat(query.After.Key, 32)
+		if err != nil {
+			return nil, cursor.ErrInvalid
+		}
+		afterRank = new(float32)
+		*afterRank = float32(rank)
+		afterType = &query.After.Type
+		afterID = &query.After.ID
+	}
+
+	rows, err := s.conn.Query(searchContent, query.Text, query.Forum, threadID, query.Author, query.From, query.To,
+		afterRank, afterType, afterID, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &search.Results{
+		Results: make([]search.Result, 0),
+	}
+	for rows.Next() {
+		var row search.Result
+		rows.Scan(&row.Type, &row.ID, &row.ThreadID, &row.ForumSlug, &row.UserNickname, &row.Created, &row.Title, &row.Snippet, &row.Rank)
+		results.Results = append(results.Results, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
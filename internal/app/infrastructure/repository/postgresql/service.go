@@ -6,11 +6,13 @@ import (
 )
 
 const (
-	clearTables           = "clearTables"
-	getPostNumberOfRows   = "getPostNumberOfRows"
-	getForumNumberOfRows  = "getForumNumberOfRows"
-	getThreadNumberOfRows = "getThreadNumberOfRows"
-	getUserNumberOfRows   = "getUserNumberOfRows"
+	clearTables              = "clearTables"
+	getPostNumberOfRows      = "getPostNumberOfRows"
+	getForumNumberOfRows     = "getForumNumberOfRows"
+	getThreadNumberOfRows    = "getThreadNumberOfRows"
+	getUserNumberOfRows      = "getUserNumberOfRows"
+	recomputeReputation      = "recomputeReputation"
+	recomputeForumReputation = "recomputeForumReputation"
 )
 
 var serviceQueries = map[string]string{
@@ -18,7 +20,49 @@ var serviceQueries = map[string]string{
 	getForumNumberOfRows:  `SELECT COUNT(*) FROM forum`,
 	getThreadNumberOfRows: `SELECT COUNT(*) FROM thread`,
 	getUserNumberOfRows:   `SELECT COUNT(*) FROM client`,
-	clearTables:           `TRUNCATE forum, thread, client, post, vote`,
+	clearTables:           `TRUNCATE forum, thread, client, post, vote, post_vote, post_reaction, post_revision, thread_revision, outbox, outbox_dead_letter, webhook, webhook_delivery, mention, thread_subscription, forum_subscription, notification, post_quarantine, forum_moderator, post_report, moderation_item, moderation_action, ban, conversation, conversation_member, private_message, tag, thread_tag, bookmark`,
+
+	recomputeReputation: `UPDATE client AS c
+	SET reputation = COALESCE(tv.rating, 0) + COALESCE(pv.rating, 0),
+		threads = COALESCE(t.count, 0),
+		posts = COALESCE(p.count, 0)
+	FROM client AS u
+	LEFT JOIN (
+		SELECT thread.user_nickname, SUM(CASE WHEN vote.voice THEN 1 ELSE -1 END) AS rating
+		FROM vote
+		JOIN thread ON (thread.id = vote.thread_id)
+		GROUP BY thread.user_nickname
+	) AS tv ON (tv.user_nickname = u.nickname)
+	LEFT JOIN (
+		SELECT post.user_nickname, SUM(CASE WHEN post_vote.voice THEN 1 ELSE -1 END) AS rating
+		FROM post_vote
+		JOIN post ON (post.id = post_vote.post_id)
+		GROUP BY post.user_nickname
+	) AS pv ON (pv.user_nickname = u.nickname)
+	LEFT JOIN (
+		SELECT user_nickname, COUNT(*) AS count
+		FROM thread
+		GROUP BY user_nickname
+	) AS t ON (t.user_nickname = u.nickname)
+	LEFT JOIN (
+		SELECT user_nickname, COUNT(*) AS count
+		FROM post
+		GROUP BY user_nickname
+	) AS p ON (p.user_nickname = u.nickname)
+	WHERE c.id = u.id`,
+
+	recomputeForumReputation: `UPDATE forum_client AS fc
+	SET reputation = COALESCE((
+		SELECT SUM(CASE WHEN vote.voice THEN 1 ELSE -1 END)
+		FROM vote
+		JOIN thread ON (thread.id = vote.thread_id)
+		WHERE thread.forum_slug = fc.forum_slug AND thread.user_nickname = fc.nickname
+	), 0) + COALESCE((
+		SELECT SUM(CASE WHEN post_vote.voice THEN 1 ELSE -1 END)
+		FROM post_vote
+		JOIN post ON (post.id = post_vote.post_id)
+		WHERE post.forum_slug = fc.forum_slug AND post.user_nickname = fc.nickname
+	), 0)`,
 }
 
 func NewServiceRepo(conn *pgx.ConnPool) *Service {
@@ -55,3 +99,19 @@ func (s *Service) Clear() error {
 	CurrentPostNumber = 0
 	return nil
 }
+
+func (s *Service) RecomputeReputation() error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{recomputeReputation, recomputeForumReputation} {
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
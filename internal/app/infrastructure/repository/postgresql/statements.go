@@ -6,6 +6,27 @@ import (
 )
 
 func PrepareStatements(conn *pgx.ConnPool) {
+	// Ban statements
+	for name, query := range banQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
+
+	// Bookmark statements
+	for name, query := range bookmarkQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
+
+	// Conversation statements
+	for name, query := range conversationQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
+
 	// Forum statements
 	for name, query := range forumQueries {
 		if _, err := conn.Prepare(name, query); err != nil {
@@ -13,6 +34,27 @@ func PrepareStatements(conn *pgx.ConnPool) {
 		}
 	}
 
+	// Moderation statements
+	for name, query := range moderationQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
+
+	// Notification statements
+	for name, query := range notificationQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
+
+	// Outbox statements
+	for name, query := range outboxQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
+
 	// Post statements
 	for name, query := range postQueries {
 		if _, err := conn.Prepare(name, query); err != nil {
@@ -20,6 +62,13 @@ func PrepareStatements(conn *pgx.ConnPool) {
 		}
 	}
 
+	// Search statements
+	for name, query := range searchQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
+
 	// Service statements
 	for name, query := range serviceQueries {
 		if _, err := conn.Prepare(name, query); err != nil {
@@ -47,4 +96,11 @@ func PrepareStatements(conn *pgx.ConnPool) {
 			log.Fatalf("[ERROR] %s. %s", name, err)
 		}
 	}
+
+	// Webhook statements
+	for name, query := range webhookQueries {
+		if _, err := conn.Prepare(name, query); err != nil {
+			log.Fatalf("[ERROR] %s. %s", name, err)
+		}
+	}
 }
@@ -2,6 +2,10 @@ package postgresql
 
 import (
 	"errors"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
@@ -19,21 +23,52 @@ const (
 	getThreadsByForumSlugLimitDesc      = "getThreadsByForumSlugLimitDesc"
 	getThreadsByForumSlugLimitSince     = "getThreadsByForumSlugLimitSince"
 	getThreadsByForumSlugLimitSinceDesc = "getThreadsByForumSlugLimitSinceDesc"
+	getThreadsByForumSlugLimitAfter     = "getThreadsByForumSlugLimitAfter"
+	getThreadsByForumSlugLimitAfterDesc = "getThreadsByForumSlugLimitAfterDesc"
 	checkThreadByIdOrSlug               = "checkThreadByIdOrSlug"
 	updateThreadVotes                   = "updateThreadVotes"
 	updateThread                        = "updateThread"
+	createThreadRevision                = "createThreadRevision"
+	getThreadRevisions                  = "getThreadRevisions"
+	getThreadsByUserLimit               = "getThreadsByUserLimit"
+	getThreadsByUserLimitDesc           = "getThreadsByUserLimitDesc"
+	getThreadsByUserLimitSince          = "getThreadsByUserLimitSince"
+	getThreadsByUserLimitSinceDesc      = "getThreadsByUserLimitSinceDesc"
+	getThreadsByUserLimitAfter          = "getThreadsByUserLimitAfter"
+	getThreadsByUserLimitAfterDesc      = "getThreadsByUserLimitAfterDesc"
+	countForumThreads                   = "countForumThreads"
+	countUserThreads                    = "countUserThreads"
+	getTagIdByName                      = "getTagIdByName"
+	createTags                          = "createTags"
+	createThreadTags                    = "createThreadTags"
+	deleteThreadTags                    = "deleteThreadTags"
+	getThreadsByForumTagLimit           = "getThreadsByForumTagLimit"
+	getThreadsByForumTagLimitDesc       = "getThreadsByForumTagLimitDesc"
+	getThreadsByForumTagLimitSince      = "getThreadsByForumTagLimitSince"
+	getThreadsByForumTagLimitSinceDesc  = "getThreadsByForumTagLimitSinceDesc"
+	getThreadsByForumTagLimitAfter      = "getThreadsByForumTagLimitAfter"
+	getThreadsByForumTagLimitAfterDesc  = "getThreadsByForumTagLimitAfterDesc"
+	getThreadsByTagLimit                = "getThreadsByTagLimit"
+	getThreadsByTagLimitDesc            = "getThreadsByTagLimitDesc"
+	getThreadsByTagLimitSince           = "getThreadsByTagLimitSince"
+	getThreadsByTagLimitSinceDesc       = "getThreadsByTagLimitSinceDesc"
+	getThreadsByTagLimitAfter           = "getThreadsByTagLimitAfter"
+	getThreadsByTagLimitAfterDesc       = "getThreadsByTagLimitAfterDesc"
+	countForumTagThreads                = "countForumTagThreads"
+	countTagThreads                     = "countTagThreads"
+	getForumTags                        = "getForumTags"
 )
 
 var threadQueries = map[string]string{
-	getThreadBySlug: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes
+	getThreadBySlug: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
 	FROM thread
 	WHERE slug = $1;`,
 
-	getThreadById: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes
+	getThreadById: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
 	FROM thread
 	WHERE id = $1;`,
 
-	getThreadByIdOrSlug: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes
+	getThreadByIdOrSlug: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
 	FROM thread
 	WHERE slug = $1 OR id::TEXT = $1`,
 
@@ -41,7 +76,7 @@ var threadQueries = map[string]string{
 	FROM thread
 	WHERE slug = $1 OR id::TEXT = $1`,
 
-	createThread: `INSERT INTO thread (slug, title, message, forum_id, forum_slug, user_nickname, created)
+	createThread: `INSERT INTO thread (slug, title, message, forum_id, forum_slug, user_nickname, created, message_html)
 	VALUES (
 		$1,
 		$2,
@@ -49,34 +84,47 @@ var threadQueries = map[string]string{
 		$4,
 		$5,
 		$6,
-		$7
+		COALESCE($7, NOW()),
+		$8
 	)
 	RETURNING id, slug, title, message, forum_slug, user_nickname, created, votes;`,
 
-	getThreadsByForumSlugLimit: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes
+	getThreadsByForumSlugLimit: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
 	FROM thread
 	WHERE forum_slug = $1
-	ORDER BY created
+	ORDER BY created, id
  	LIMIT $2;`,
 
-	getThreadsByForumSlugLimitDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes
+	getThreadsByForumSlugLimitDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
 	FROM thread
 	WHERE forum_slug = $1
-	ORDER BY created DESC
+	ORDER BY created DESC, id DESC
 	LIMIT $2;`,
 
-	getThreadsByForumSlugLimitSince: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes
+	getThreadsByForumSlugLimitSince: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
 	FROM thread
 	WHERE forum_slug = $1 AND created >= $3::TEXT::TIMESTAMPTZ
-	ORDER BY created
+	ORDER BY created, id
 	LIMIT $2;`,
 
-	getThreadsByForumSlugLimitSinceDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes
+	getThreadsByForumSlugLimitSinceDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
 	FROM thread
 	WHERE forum_slug = $1 AND created <= $3::TEXT::TIMESTAMPTZ
-	ORDER BY created DESC
+	ORDER BY created DESC, id DESC
  	LIMIT $2;`,
 
+	getThreadsByForumSlugLimitAfter: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE forum_slug = $1 AND (created, id) > ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY created, id
+	LIMIT $2;`,
+
+	getThreadsByForumSlugLimitAfterDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE forum_slug = $1 AND (created, id) < ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY created DESC, id DESC
+	LIMIT $2;`,
+
 	checkThreadByIdOrSlug: `SELECT id, slug
 	FROM thread
 	WHERE slug = $1 OR id::TEXT = $1`,
@@ -84,13 +132,181 @@ var threadQueries = map[string]string{
 	updateThreadVotes: `UPDATE thread
 	SET votes = votes + $1
 	WHERE id = $2
-	RETURNING id, slug, title, message, forum_slug, user_nickname, created, votes`,
+	RETURNING id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')`,
 
 	updateThread: `UPDATE thread
 	SET title = COALESCE($1, title), 
-			message = COALESCE($2, message)
+			message = COALESCE($2, message),
+			message_html = COALESCE($4, message_html)
 	WHERE id = $3
-	RETURNING id, slug, title, message, forum_slug, user_nickname, created, votes`,
+	RETURNING id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')`,
+
+	createThreadRevision: `INSERT INTO thread_revision (thread_id, title, message, editor)
+	VALUES ($1, $2, $3, $4);`,
+
+	getThreadRevisions: `SELECT id, thread_id, title, message, editor, created
+	FROM thread_revision
+	WHERE thread_id = $1
+	ORDER BY id;`,
+
+	getThreadsByUserLimit: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE user_nickname = $1
+	ORDER BY created, id
+	LIMIT $2;`,
+
+	getThreadsByUserLimitDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE user_nickname = $1
+	ORDER BY created DESC, id DESC
+	LIMIT $2;`,
+
+	getThreadsByUserLimitSince: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE user_nickname = $1 AND created >= $3::TEXT::TIMESTAMPTZ
+	ORDER BY created, id
+	LIMIT $2;`,
+
+	getThreadsByUserLimitSinceDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE user_nickname = $1 AND created <= $3::TEXT::TIMESTAMPTZ
+	ORDER BY created DESC, id DESC
+	LIMIT $2;`,
+
+	getThreadsByUserLimitAfter: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE user_nickname = $1 AND (created, id) > ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY created, id
+	LIMIT $2;`,
+
+	getThreadsByUserLimitAfterDesc: `SELECT id, slug, title, message, forum_slug, user_nickname, created, votes, thread_tags(id), COALESCE(message_html, '')
+	FROM thread
+	WHERE user_nickname = $1 AND (created, id) < ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY created DESC, id DESC
+	LIMIT $2;`,
+
+	countForumThreads: `SELECT threads
+	FROM forum
+	WHERE slug = $1;`,
+
+	countUserThreads: `SELECT threads
+	FROM client
+	WHERE nickname = $1;`,
+
+	getTagIdByName: `SELECT id
+	FROM tag
+	WHERE name = $1;`,
+
+	createTags: `INSERT INTO tag (name)
+	SELECT unnest($1::TEXT[])
+	ON CONFLICT DO NOTHING;`,
+
+	createThreadTags: `INSERT INTO thread_tag (tag_id, thread_id, forum_slug, created)
+	SELECT id, $2::INTEGER, $3::CITEXT, $4::TIMESTAMPTZ
+	FROM tag
+	WHERE name = ANY($1::TEXT[])
+	ON CONFLICT DO NOTHING;`,
+
+	deleteThreadTags: `DELETE FROM thread_tag
+	WHERE thread_id = $1;`,
+
+	getThreadsByForumTagLimit: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.forum_slug = $1 AND tt.tag_id = $3
+	ORDER BY tt.created, tt.thread_id
+	LIMIT $2;`,
+
+	getThreadsByForumTagLimitDesc: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.forum_slug = $1 AND tt.tag_id = $3
+	ORDER BY tt.created DESC, tt.thread_id DESC
+	LIMIT $2;`,
+
+	getThreadsByForumTagLimitSince: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.forum_slug = $1 AND tt.tag_id = $4 AND tt.created >= $3::TEXT::TIMESTAMPTZ
+	ORDER BY tt.created, tt.thread_id
+	LIMIT $2;`,
+
+	getThreadsByForumTagLimitSinceDesc: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.forum_slug = $1 AND tt.tag_id = $4 AND tt.created <= $3::TEXT::TIMESTAMPTZ
+	ORDER BY tt.created DESC, tt.thread_id DESC
+	LIMIT $2;`,
+
+	getThreadsByForumTagLimitAfter: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.forum_slug = $1 AND tt.tag_id = $5 AND (tt.created, tt.thread_id) > ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY tt.created, tt.thread_id
+	LIMIT $2;`,
+
+	getThreadsByForumTagLimitAfterDesc: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.forum_slug = $1 AND tt.tag_id = $5 AND (tt.created, tt.thread_id) < ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY tt.created DESC, tt.thread_id DESC
+	LIMIT $2;`,
+
+	getThreadsByTagLimit: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.tag_id = $1
+	ORDER BY tt.created, tt.thread_id
+	LIMIT $2;`,
+
+	getThreadsByTagLimitDesc: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.tag_id = $1
+	ORDER BY tt.created DESC, tt.thread_id DESC
+	LIMIT $2;`,
+
+	getThreadsByTagLimitSince: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.tag_id = $1 AND tt.created >= $3::TEXT::TIMESTAMPTZ
+	ORDER BY tt.created, tt.thread_id
+	LIMIT $2;`,
+
+	getThreadsByTagLimitSinceDesc: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.tag_id = $1 AND tt.created <= $3::TEXT::TIMESTAMPTZ
+	ORDER BY tt.created DESC, tt.thread_id DESC
+	LIMIT $2;`,
+
+	getThreadsByTagLimitAfter: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.tag_id = $1 AND (tt.created, tt.thread_id) > ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY tt.created, tt.thread_id
+	LIMIT $2;`,
+
+	getThreadsByTagLimitAfterDesc: `SELECT t.id, t.slug, t.title, t.message, t.forum_slug, t.user_nickname, t.created, t.votes, thread_tags(t.id), COALESCE(t.message_html, '')
+	FROM thread_tag AS tt
+	JOIN thread AS t ON (t.id = tt.thread_id)
+	WHERE tt.tag_id = $1 AND (tt.created, tt.thread_id) < ($3::TEXT::TIMESTAMPTZ, $4)
+	ORDER BY tt.created DESC, tt.thread_id DESC
+	LIMIT $2;`,
+
+	countForumTagThreads: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM thread_tag WHERE forum_slug = $1 AND tag_id = $2 LIMIT $3) AS capped;`,
+
+	countTagThreads: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM thread_tag WHERE tag_id = $1 LIMIT $2) AS capped;`,
+
+	getForumTags: `SELECT g.name, COUNT(*) AS threads
+	FROM thread_tag AS tt
+	JOIN tag AS g ON (g.id = tt.tag_id)
+	WHERE tt.forum_slug = $1
+	GROUP BY g.name
+	ORDER BY threads DESC, g.name
+	LIMIT $2;`,
 }
 
 func NewThreadRepo(conn *pgx.ConnPool) *Thread {
@@ -103,7 +319,7 @@ type Thread struct {
 	conn *pgx.ConnPool
 }
 
-func (t *Thread) CreateThread(data *thread.Create) (*thread.Thread, error) {
+func (t *Thread) CreateThread(data *thread.Create, emit func(*thread.Thread) event.Typed) (*thread.Thread, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -117,19 +333,30 @@ func (t *Thread) CreateThread(data *thread.Create) (*thread.Thread, error) {
 		return nil, err
 	}
 
-	if err := tx.QueryRow(getForumIdAndSlugBySlug, data.ForumSlug).Scan(&forumID, &data.ForumSlug); err != nil {
+	var category bool
+	if err := tx.QueryRow(getForumIdAndSlugBySlug, data.ForumSlug).Scan(&forumID, &data.ForumSlug, &category); err != nil {
 		return nil, err
 	}
 
+	if category {
+		return nil, errors.New("forumIsCategory")
+	}
+
 	received := &thread.Thread{}
 
 	if data.Slug != nil {
-		if err := tx.QueryRow(getThreadBySlug, data.Slug).Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes); err == nil {
+		if err := tx.QueryRow(getThreadBySlug, data.Slug).Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes, &received.Tags, &received.Rendered); err == nil {
 			return received, errors.New("threadAlreadyExists")
 		}
 	}
 
-	if err := tx.QueryRow(createThread, data.Slug, data.Title, data.Message, forumID, data.ForumSlug, data.UserNickname, data.Created).Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes); err != nil {
+	if err := tx.QueryRow(createThread, data.Slug, data.Title, data.Message, forumID, data.ForumSlug, data.UserNickname, data.Created, data.Rendered).Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes); err != nil {
+		return nil, err
+	}
+	received.Tags = data.Tags
+	received.Rendered = data.Rendered
+
+	if err := tagThread(tx, received); err != nil {
 		return nil, err
 	}
 
@@ -141,20 +368,38 @@ func (t *Thread) CreateThread(data *thread.Create) (*thread.Thread, error) {
 		return nil, err
 	}
 
+	if _, err := tx.Exec(updateUserThreads, data.UserNickname); err != nil {
+		return nil, err
+	}
+
+	if err := recordEvent(tx, emit(received)); err != nil {
+		return nil, err
+	}
+
 	tx.Commit()
 	return received, nil
 }
 
-func (t *Thread) GetThreads(slug string, limit *int, since *string, orderDesc bool) (*thread.Threads, error) {
+func (t *Thread) GetThreads(slug string, tag *string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error) {
 	if err := t.conn.QueryRow(getForumSlugBySlug, slug).Scan(&slug); err != nil {
 		return nil, err
 	}
 
+	if tag != nil {
+		return t.getForumTagThreads(slug, *tag, limit, since, after, orderDesc)
+	}
+
 	threads := make(thread.Threads, 0)
 	var err error
 	var rows *pgx.Rows
 
-	if since == nil {
+	if after != nil {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByForumSlugLimitAfterDesc, slug, limit, after.Key, after.ID)
+		} else {
+			rows, err = t.conn.Query(getThreadsByForumSlugLimitAfter, slug, limit, after.Key, after.ID)
+		}
+	} else if since == nil {
 		if orderDesc {
 			rows, err = t.conn.Query(getThreadsByForumSlugLimitDesc, slug, limit)
 		} else {
@@ -174,7 +419,7 @@ func (t *Thread) GetThreads(slug string, limit *int, since *string, orderDesc bo
 
 	for rows.Next() {
 		var row thread.Thread
-		rows.Scan(&row.ID, &row.Slug, &row.Title, &row.Message, &row.ForumSlug, &row.UserNickname, &row.Created, &row.Votes)
+		rows.Scan(&row.ID, &row.Slug, &row.Title, &row.Message, &row.ForumSlug, &row.UserNickname, &row.Created, &row.Votes, &row.Tags, &row.Rendered)
 		threads = append(threads, row)
 	}
 
@@ -184,33 +429,299 @@ func (t *Thread) GetThreads(slug string, limit *int, since *string, orderDesc bo
 func (t *Thread) GetThread(slugOrId string) (*thread.Thread, error) {
 	var received thread.Thread
 	if err := t.conn.QueryRow(getThreadByIdOrSlug, slugOrId).
-		Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes); err != nil {
+		Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes, &received.Tags, &received.Rendered); err != nil {
 		return nil, err
 	}
 
 	return &received, nil
 }
 
-func (t *Thread) UpdateThread(data *thread.Update, slugOrId string) (*thread.Thread, error) {
+func (t *Thread) UpdateThread(data *thread.Update, slugOrId string, emit func(*thread.Thread) event.Typed) (*thread.Thread, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
-	var threadID uint64
-	var threadSlug string
-	if err := tx.QueryRow(checkThreadByIdOrSlug, slugOrId).
-		Scan(&threadID, &threadSlug); err != nil {
+	var current thread.Thread
+	if err := tx.QueryRow(getThreadByIdOrSlug, slugOrId).
+		Scan(&current.ID, &current.Slug, &current.Title, &current.Message, &current.ForumSlug, &current.UserNickname, &current.Created, &current.Votes, &current.Tags, &current.Rendered); err != nil {
 		return nil, err
 	}
 
+	titleChanged := data.Title != nil && *data.Title != current.Title
+	messageChanged := data.Message != nil && *data.Message != current.Message
+	if titleChanged || messageChanged {
+		// Edits made without an explicit editor are attributed to the author
+		editor := current.UserNickname
+		if data.Editor != nil {
+			if err := tx.QueryRow(getUserByNickname, data.Editor).Scan(&editor); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := tx.Exec(createThreadRevision, current.ID, current.Title, current.Message, editor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Tags are replaced first, so the updated thread is returned with the new ones
+	if data.Tags != nil {
+		if _, err := tx.Exec(deleteThreadTags, current.ID); err != nil {
+			return nil, err
+		}
+
+		current.Tags = *data.Tags
+		if err := tagThread(tx, &current); err != nil {
+			return nil, err
+		}
+	}
+
 	var updated thread.Thread
-	if err := tx.QueryRow(updateThread, data.Title, data.Message, threadID).
-		Scan(&updated.ID, &updated.Slug, &updated.Title, &updated.Message, &updated.ForumSlug, &updated.UserNickname, &updated.Created, &updated.Votes); err != nil {
+	if err := tx.QueryRow(updateThread, data.Title, data.Message, current.ID, data.Rendered).
+		Scan(&updated.ID, &updated.Slug, &updated.Title, &updated.Message, &updated.ForumSlug, &updated.UserNickname, &updated.Created, &updated.Votes, &updated.Tags, &updated.Rendered); err != nil {
 		return nil, err
 	}
 
+	if titleChanged || messageChanged || data.Tags != nil {
+		if err := recordEvent(tx, emit(&updated)); err != nil {
+			return nil, err
+		}
+	}
+
 	tx.Commit()
 	return &updated, nil
 }
+
+func (t *Thread) GetThreadHistory(slugOrId string) (*thread.Revisions, error) {
+	var threadID uint64
+	var slug *string
+	if err := t.conn.QueryRow(checkThreadByIdOrSlug, slugOrId).
+		Scan(&threadID, &slug); err != nil {
+		return nil, err
+	}
+
+	rows, err := t.conn.Query(getThreadRevisions, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make(thread.Revisions, 0)
+	for rows.Next() {
+		var row thread.Revision
+		rows.Scan(&row.ID, &row.ThreadID, &row.Title, &row.Message, &row.Editor, &row.Created)
+		revisions = append(revisions, row)
+	}
+
+	return &revisions, nil
+}
+
+func (t *Thread) GetUserThreads(nickname string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error) {
+	if err := t.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	threads := make(thread.Threads, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if after != nil {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByUserLimitAfterDesc, nickname, limit, after.Key, after.ID)
+		} else {
+			rows, err = t.conn.Query(getThreadsByUserLimitAfter, nickname, limit, after.Key, after.ID)
+		}
+	} else if since == nil {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByUserLimitDesc, nickname, limit)
+		} else {
+			rows, err = t.conn.Query(getThreadsByUserLimit, nickname, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByUserLimitSinceDesc, nickname, limit, since)
+		} else {
+			rows, err = t.conn.Query(getThreadsByUserLimitSince, nickname, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row thread.Thread
+		rows.Scan(&row.ID, &row.Slug, &row.Title, &row.Message, &row.ForumSlug, &row.UserNickname, &row.Created, &row.Votes, &row.Tags, &row.Rendered)
+		threads = append(threads, row)
+	}
+
+	return &threads, nil
+}
+
+func (t *Thread) CountThreads(slug string, tag *string) (*page.Total, error) {
+	if tag == nil {
+		return countExact(t.conn, countForumThreads, slug)
+	}
+
+	var tagID uint64
+	if err := t.conn.QueryRow(getTagIdByName, *tag).Scan(&tagID); err != nil {
+		if err == pgx.ErrNoRows {
+			return &page.Total{}, nil
+		}
+		return nil, err
+	}
+
+	return countCapped(t.conn, countForumTagThreads, slug, tagID)
+}
+
+func (t *Thread) CountTagThreads(tag string) (*page.Total, error) {
+	var tagID uint64
+	if err := t.conn.QueryRow(getTagIdByName, tag).Scan(&tagID); err != nil {
+		if err == pgx.ErrNoRows {
+			return &page.Total{}, nil
+		}
+		return nil, err
+	}
+
+	return countCapped(t.conn, countTagThreads, tagID)
+}
+
+func (t *Thread) CountUserThreads(nickname string) (*page.Total, error) {
+	return countExact(t.conn, countUserThreads, nickname)
+}
+
+// GetTagThreads lists the threads carrying the tag across all forums, an unknown tag gives an empty list
+// as in the forum listing
+func (t *Thread) GetTagThreads(tag string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error) {
+	threads := make(thread.Threads, 0)
+
+	var tagID uint64
+	if err := t.conn.QueryRow(getTagIdByName, tag).Scan(&tagID); err != nil {
+		if err == pgx.ErrNoRows {
+			return &threads, nil
+		}
+		return nil, err
+	}
+
+	var err error
+	var rows *pgx.Rows
+
+	if after != nil {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByTagLimitAfterDesc, tagID, limit, after.Key, after.ID)
+		} else {
+			rows, err = t.conn.Query(getThreadsByTagLimitAfter, tagID, limit, after.Key, after.ID)
+		}
+	} else if since == nil {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByTagLimitDesc, tagID, limit)
+		} else {
+			rows, err = t.conn.Query(getThreadsByTagLimit, tagID, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByTagLimitSinceDesc, tagID, limit, since)
+		} else {
+			rows, err = t.conn.Query(getThreadsByTagLimitSince, tagID, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row thread.Thread
+		rows.Scan(&row.ID, &row.Slug, &row.Title, &row.Message, &row.ForumSlug, &row.UserNickname, &row.Created, &row.Votes, &row.Tags, &row.Rendered)
+		threads = append(threads, row)
+	}
+
+	return &threads, nil
+}
+
+// GetForumTags returns the tags used in the forum with the number of threads carrying each, most used first
+func (t *Thread) GetForumTags(slug string, limit *int) (*thread.Tags, error) {
+	if err := t.conn.QueryRow(getForumSlugBySlug, slug).Scan(&slug); err != nil {
+		return nil, err
+	}
+
+	rows, err := t.conn.Query(getForumTags, slug, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(thread.Tags, 0)
+	for rows.Next() {
+		var row thread.Tag
+		rows.Scan(&row.Name, &row.Threads)
+		tags = append(tags, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &tags, nil
+}
+
+// getForumTagThreads lists the forum threads carrying the tag, an unknown tag gives an empty list
+func (t *Thread) getForumTagThreads(slug string, tag string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error) {
+	threads := make(thread.Threads, 0)
+
+	var tagID uint64
+	if err := t.conn.QueryRow(getTagIdByName, tag).Scan(&tagID); err != nil {
+		if err == pgx.ErrNoRows {
+			return &threads, nil
+		}
+		return nil, err
+	}
+
+	var err error
+	var rows *pgx.Rows
+
+	if after != nil {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByForumTagLimitAfterDesc, slug, limit, after.Key, after.ID, tagID)
+		} else {
+			rows, err = t.conn.Query(getThreadsByForumTagLimitAfter, slug, limit, after.Key, after.ID, tagID)
+		}
+	} else if since == nil {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByForumTagLimitDesc, slug, limit, tagID)
+		} else {
+			rows, err = t.conn.Query(getThreadsByForumTagLimit, slug, limit, tagID)
+		}
+	} else {
+		if orderDesc {
+			rows, err = t.conn.Query(getThreadsByForumTagLimitSinceDesc, slug, limit, since, tagID)
+		} else {
+			rows, err = t.conn.Query(getThreadsByForumTagLimitSince, slug, limit, since, tagID)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row thread.Thread
+		rows.Scan(&row.ID, &row.Slug, &row.Title, &row.Message, &row.ForumSlug, &row.UserNickname, &row.Created, &row.Votes, &row.Tags, &row.Rendered)
+		threads = append(threads, row)
+	}
+
+	return &threads, nil
+}
+
+// tagThread links the thread to its tags, creating the tags used for the first time
+func tagThread(tx *pgx.Tx, tagged *thread.Thread) error {
+	if len(tagged.Tags) == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(createTags, tagged.Tags); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(createThreadTags, tagged.Tags, tagged.ID, tagged.ForumSlug, tagged.Created)
+	return err
+}
@@ -2,17 +2,50 @@ package postgresql
 
 import (
 	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 	"github.com/jackc/pgx"
 )
 
 const (
-	getUserInfoByNickname        = "getUserInfoByNickname"
-	updateUser                   = "updateUser"
-	getUsersWithEmailAndNickname = "getUsersWithEmailAndNickname"
-	createUser                   = "createUser"
-	getUserByNickname            = "getUserByNickname"
-	createForumUser              = "createForumUser"
+	getUserInfoByNickname                = "getUserInfoByNickname"
+	updateUser                           = "updateUser"
+	getUsersWithEmailAndNickname         = "getUsersWithEmailAndNickname"
+	createUser                           = "createUser"
+	getUserByNickname                    = "getUserByNickname"
+	createForumUser                      = "createForumUser"
+	getUserProfileByNickname             = "getUserProfileByNickname"
+	updateUserReputation                 = "updateUserReputation"
+	updateForumUserReputation            = "updateForumUserReputation"
+	updateUserThreads                    = "updateUserThreads"
+	updateUserPosts                      = "updateUserPosts"
+	getUsersLimit                        = "getUsersLimit"
+	getUsersLimitDesc                    = "getUsersLimitDesc"
+	getUsersLimitSince                   = "getUsersLimitSince"
+	getUsersLimitSinceDesc               = "getUsersLimitSinceDesc"
+	getUsersJoinedLimit                  = "getUsersJoinedLimit"
+	getUsersJoinedLimitDesc              = "getUsersJoinedLimitDesc"
+	getUsersJoinedLimitSince             = "getUsersJoinedLimitSince"
+	getUsersJoinedLimitSinceDesc         = "getUsersJoinedLimitSinceDesc"
+	getUsersJoinedLimitAfter             = "getUsersJoinedLimitAfter"
+	getUsersJoinedLimitAfterDesc         = "getUsersJoinedLimitAfterDesc"
+	countUsers                           = "countUsers"
+	getUsersByPrefixLimit                = "getUsersByPrefixLimit"
+	getUsersByPrefixLimitDesc            = "getUsersByPrefixLimitDesc"
+	getUsersByPrefixLimitSince           = "getUsersByPrefixLimitSince"
+	getUsersByPrefixLimitSinceDesc       = "getUsersByPrefixLimitSinceDesc"
+	getUsersByPrefixJoinedLimit          = "getUsersByPrefixJoinedLimit"
+	getUsersByPrefixJoinedLimitDesc      = "getUsersByPrefixJoinedLimitDesc"
+	getUsersByPrefixJoinedLimitSince     = "getUsersByPrefixJoinedLimitSince"
+	getUsersByPrefixJoinedLimitSinceDesc = "getUsersByPrefixJoinedLimitSinceDesc"
+	getUsersByPrefixJoinedLimitAfter     = "getUsersByPrefixJoinedLimitAfter"
+	getUsersByPrefixJoinedLimitAfterDesc = "getUsersByPrefixJoinedLimitAfterDesc"
+	countUsersByPrefix                   = "countUsersByPrefix"
 )
 
 var userQueries = map[string]string{
@@ -24,7 +57,7 @@ var userQueries = map[string]string{
 		fullname = COALESCE($2, fullname),
 		about = COALESCE($3, about)
 	WHERE nickname = $4 
-	RETURNING email, nickname, fullname, about;`,
+	RETURNING email, nickname, fullname, about, reputation, posts, threads;`,
 
 	getUsersWithEmailAndNickname: `SELECT nickname, email, fullname, about
 	FROM client 
@@ -41,6 +74,185 @@ var userQueries = map[string]string{
 	createForumUser: `INSERT INTO forum_client(forum_slug, email, nickname, fullname, about)
 	VALUES ($1, $2, $3, $4, $5)
 	ON CONFLICT DO NOTHING;`,
+
+	getUserProfileByNickname: `SELECT nickname, email, fullname, about, reputation, posts, threads
+	FROM client WHERE nickname = $1;`,
+
+	updateUserReputation: `UPDATE client
+	SET reputation = reputation + $1
+	WHERE nickname = $2;`,
+
+	updateForumUserReputation: `UPDATE forum_client
+	SET reputation = reputation + $1
+	WHERE forum_slug = $2 AND nickname = $3;`,
+
+	updateUserThreads: `UPDATE client
+	SET threads = threads + 1
+	WHERE nickname = $1;`,
+
+	updateUserPosts: `UPDATE client
+	SET posts = posts + $1
+	WHERE nickname = $2;`,
+
+	getUsersLimit: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	ORDER BY nickname
+	LIMIT $1;`,
+
+	getUsersLimitDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	ORDER BY nickname DESC
+	LIMIT $1;`,
+
+	getUsersLimitSince: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE nickname > $2
+	ORDER BY nickname
+	LIMIT $1;`,
+
+	getUsersLimitSinceDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE nickname < $2
+	ORDER BY nickname DESC
+	LIMIT $1;`,
+
+	getUsersJoinedLimit: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	ORDER BY id
+	LIMIT $1;`,
+
+	getUsersJoinedLimitDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	ORDER BY id DESC
+	LIMIT $1;`,
+
+	getUsersJoinedLimitSince: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE id > (SELECT id FROM client WHERE nickname = $2)
+	ORDER BY id
+	LIMIT $1;`,
+
+	getUsersJoinedLimitSinceDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE id < (SELECT id FROM client WHERE nickname = $2)
+	ORDER BY id DESC
+	LIMIT $1;`,
+
+	getUsersJoinedLimitAfter: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE id > $2::TEXT::INTEGER
+	ORDER BY id
+	LIMIT $1;`,
+
+	getUsersJoinedLimitAfterDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE id < $2::TEXT::INTEGER
+	ORDER BY id DESC
+	LIMIT $1;`,
+
+	countUsers: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM client LIMIT $1) AS capped;`,
+
+	// Prefix searches compare bytewise against the bounds of the prefix, which the
+	// text_pattern_ops indexes on lower(nickname) and lower(fullname) serve
+	getUsersByPrefixLimit: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+	ORDER BY nickname
+	LIMIT $3;`,
+
+	getUsersByPrefixLimitDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+	ORDER BY nickname DESC
+	LIMIT $3;`,
+
+	getUsersByPrefixLimitSince: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+		AND nickname > $4
+	ORDER BY nickname
+	LIMIT $3;`,
+
+	getUsersByPrefixLimitSinceDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+		AND nickname < $4
+	ORDER BY nickname DESC
+	LIMIT $3;`,
+
+	getUsersByPrefixJoinedLimit: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+	ORDER BY id
+	LIMIT $3;`,
+
+	getUsersByPrefixJoinedLimitDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+	ORDER BY id DESC
+	LIMIT $3;`,
+
+	getUsersByPrefixJoinedLimitSince: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+		AND id > (SELECT id FROM client WHERE nickname = $4)
+	ORDER BY id
+	LIMIT $3;`,
+
+	getUsersByPrefixJoinedLimitSinceDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+		AND id < (SELECT id FROM client WHERE nickname = $4)
+	ORDER BY id DESC
+	LIMIT $3;`,
+
+	getUsersByPrefixJoinedLimitAfter: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+		AND id > $4::TEXT::INTEGER
+	ORDER BY id
+	LIMIT $3;`,
+
+	getUsersByPrefixJoinedLimitAfterDesc: `SELECT id, email, nickname, fullname, about, reputation, posts, threads
+	FROM client
+	WHERE ((lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+		OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2))
+		AND id < $4::TEXT::INTEGER
+	ORDER BY id DESC
+	LIMIT $3;`,
+
+	countUsersByPrefix: `SELECT COUNT(*)
+	FROM (
+		SELECT 1
+		FROM client
+		WHERE (lower(nickname::TEXT) ~>=~ $1 AND lower(nickname::TEXT) ~<~ $2)
+			OR (lower(fullname) ~>=~ $1 AND lower(fullname) ~<~ $2)
+		LIMIT $3
+	) AS capped;`,
+}
+
+// usersByPrefix holds the prefix filtered variant of every users statement
+var usersByPrefix = map[string]string{
+	getUsersLimit:                getUsersByPrefixLimit,
+	getUsersLimitDesc:            getUsersByPrefixLimitDesc,
+	getUsersLimitSince:           getUsersByPrefixLimitSince,
+	getUsersLimitSinceDesc:       getUsersByPrefixLimitSinceDesc,
+	getUsersJoinedLimit:          getUsersByPrefixJoinedLimit,
+	getUsersJoinedLimitDesc:      getUsersByPrefixJoinedLimitDesc,
+	getUsersJoinedLimitSince:     getUsersByPrefixJoinedLimitSince,
+	getUsersJoinedLimitSinceDesc: getUsersByPrefixJoinedLimitSinceDesc,
+	getUsersJoinedLimitAfter:     getUsersByPrefixJoinedLimitAfter,
+	getUsersJoinedLimitAfterDesc: getUsersByPrefixJoinedLimitAfterDesc,
 }
 
 func NewUserRepo(conn *pgx.ConnPool) *User {
@@ -55,7 +267,8 @@ type User struct {
 
 func (u *User) GetUserByNickname(nickname string) (*user.User, error) {
 	received := &user.User{}
-	if err := u.conn.QueryRow(getUserInfoByNickname, nickname).Scan(&received.Nickname, &received.Email, &received.Fullname, &received.About); err != nil {
+	if err := u.conn.QueryRow(getUserProfileByNickname, nickname).
+		Scan(&received.Nickname, &received.Email, &received.Fullname, &received.About, &received.Reputation, &received.Posts, &received.Threads); err != nil {
 		return nil, err
 	}
 
@@ -71,7 +284,7 @@ func (u *User) UpdateUser(data *user.Update, nickname string) (*user.User, error
 	}
 	defer tx.Rollback()
 
-	if err = tx.QueryRow(updateUser, data.Email, data.Fullname, data.About, nickname).Scan(&updated.Email, &updated.Nickname, &updated.Fullname, &updated.About); err != nil {
+	if err = tx.QueryRow(updateUser, data.Email, data.Fullname, data.About, nickname).Scan(&updated.Email, &updated.Nickname, &updated.Fullname, &updated.About, &updated.Reputation, &updated.Posts, &updated.Threads); err != nil {
 		return nil, err
 	}
 
@@ -79,7 +292,7 @@ func (u *User) UpdateUser(data *user.Update, nickname string) (*user.User, error
 	return updated, nil
 }
 
-func (u *User) CreateUser(data *user.User) (*user.Users, error) {
+func (u *User) CreateUser(data *user.User, emit func(*user.User) event.Typed) (*user.Users, error) {
 	tx, err := u.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -104,9 +317,103 @@ func (u *User) CreateUser(data *user.User) (*user.Users, error) {
 		}
 		users = append(users, created)
 
+		if err := recordEvent(tx, emit(&created)); err != nil {
+			return nil, err
+		}
+
 		tx.Commit()
 		return &users, nil
 	}
 
 	return &users, errors.New("conflict")
 }
+
+func (u *User) GetUsers(query *string, sort string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*user.Users, error) {
+	var statement string
+	var key *string
+
+	if sort == user.SortByJoined {
+		if after != nil {
+			key = &after.Key
+			if orderDesc {
+				statement = getUsersJoinedLimitAfterDesc
+			} else {
+				statement = getUsersJoinedLimitAfter
+			}
+		} else if since == nil {
+			if orderDesc {
+				statement = getUsersJoinedLimitDesc
+			} else {
+				statement = getUsersJoinedLimit
+			}
+		} else {
+			key = since
+			if orderDesc {
+				statement = getUsersJoinedLimitSinceDesc
+			} else {
+				statement = getUsersJoinedLimitSince
+			}
+		}
+	} else {
+		if after != nil {
+			since = &after.Key
+		}
+		if since == nil {
+			if orderDesc {
+				statement = getUsersLimitDesc
+			} else {
+				statement = getUsersLimit
+			}
+		} else {
+			key = since
+			if orderDesc {
+				statement = getUsersLimitSinceDesc
+			} else {
+				statement = getUsersLimitSince
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 4)
+	if lower, upper, ok := prefixBounds(query); ok {
+		statement = usersByPrefix[statement]
+		args = append(args, lower, upper)
+	}
+	args = append(args, limit)
+	if key != nil {
+		args = append(args, key)
+	}
+
+	rows, err := u.conn.Query(statement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(user.Users, 0)
+	for rows.Next() {
+		var received user.User
+		rows.Scan(&received.ID, &received.Email, &received.Nickname, &received.Fullname, &received.About, &received.Reputation, &received.Posts, &received.Threads)
+		users = append(users, received)
+	}
+
+	return &users, nil
+}
+
+func (u *User) CountUsers(query *string) (*page.Total, error) {
+	if lower, upper, ok := prefixBounds(query); ok {
+		return countCapped(u.conn, countUsersByPrefix, lower, upper)
+	}
+
+	return countCapped(u.conn, countUsers)
+}
+
+// prefixBounds returns the lowercased query and the smallest string above everything it prefixes.
+// U+10FFFF is the largest code point and a noncharacter, so no stored name continues with it.
+func prefixBounds(query *string) (string, string, bool) {
+	if query == nil || *query == "" {
+		return "", "", false
+	}
+
+	lower := strings.ToLower(*query)
+	return lower, lower + string(utf8.MaxRune), true
+}
@@ -1,15 +1,36 @@
 package postgresql
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/vote"
 	"github.com/jackc/pgx"
 )
 
 const (
-	createVote = "createVote"
-	getVote    = "getVote"
-	updateVote = "updateVote"
+	createVote                   = "createVote"
+	getVote                      = "getVote"
+	updateVote                   = "updateVote"
+	deleteVote                   = "deleteVote"
+	getThreadVotesLimit          = "getThreadVotesLimit"
+	getThreadVotesLimitDesc      = "getThreadVotesLimitDesc"
+	getThreadVotesLimitSince     = "getThreadVotesLimitSince"
+	getThreadVotesLimitSinceDesc = "getThreadVotesLimitSinceDesc"
+	getUserVotesLimit            = "getUserVotesLimit"
+	getUserVotesLimitDesc        = "getUserVotesLimitDesc"
+	getUserVotesLimitSince       = "getUserVotesLimitSince"
+	getUserVotesLimitSinceDesc   = "getUserVotesLimitSinceDesc"
+	checkPostById                = "checkPostById"
+	createPostVote               = "createPostVote"
+	deletePostVote               = "deletePostVote"
+	updatePostVotes              = "updatePostVotes"
+	createPostReaction           = "createPostReaction"
+	deletePostReaction           = "deletePostReaction"
+	updatePostReactions          = "updatePostReactions"
+	countThreadVotes             = "countThreadVotes"
+	countUserVotes               = "countUserVotes"
 )
 
 var voteQueries = map[string]string{
@@ -23,6 +44,101 @@ var voteQueries = map[string]string{
 	updateVote: `UPDATE vote
 	SET voice = $1
 	WHERE id = $2`,
+
+	deleteVote: `DELETE FROM vote
+	WHERE user_nickname = $1 AND thread_id = $2
+	RETURNING voice`,
+
+	getThreadVotesLimit: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE thread_id = $1
+	ORDER BY user_nickname
+	LIMIT $2;`,
+
+	getThreadVotesLimitDesc: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE thread_id = $1
+	ORDER BY user_nickname DESC
+	LIMIT $2;`,
+
+	getThreadVotesLimitSince: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE thread_id = $1 AND user_nickname > $3
+	ORDER BY user_nickname
+	LIMIT $2;`,
+
+	getThreadVotesLimitSinceDesc: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE thread_id = $1 AND user_nickname < $3
+	ORDER BY user_nickname DESC
+	LIMIT $2;`,
+
+	getUserVotesLimit: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE user_nickname = $1
+	ORDER BY thread_id
+	LIMIT $2;`,
+
+	getUserVotesLimitDesc: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE user_nickname = $1
+	ORDER BY thread_id DESC
+	LIMIT $2;`,
+
+	getUserVotesLimitSince: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE user_nickname = $1 AND thread_id > $3::TEXT::INTEGER
+	ORDER BY thread_id
+	LIMIT $2;`,
+
+	getUserVotesLimitSinceDesc: `SELECT CASE WHEN voice THEN 1 ELSE -1 END, user_nickname, thread_id
+	FROM vote
+	WHERE user_nickname = $1 AND thread_id < $3::TEXT::INTEGER
+	ORDER BY thread_id DESC
+	LIMIT $2;`,
+
+	checkPostById: `SELECT id
+	FROM post
+	WHERE id = $1`,
+
+	// No row is returned when the vote is repeated, inserted tells a new vote from a changed one
+	createPostVote: `INSERT INTO post_vote (voice, user_nickname, post_id)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (post_id, user_nickname) DO UPDATE
+	SET voice = EXCLUDED.voice
+	WHERE post_vote.voice IS DISTINCT FROM EXCLUDED.voice
+	RETURNING xmax = 0`,
+
+	deletePostVote: `DELETE FROM post_vote
+	WHERE user_nickname = $1 AND post_id = $2
+	RETURNING voice`,
+
+	updatePostVotes: `UPDATE post
+	SET votes = votes + $1
+	WHERE id = $2
+	RETURNING id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')`,
+
+	createPostReaction: `INSERT INTO post_reaction (post_id, user_nickname, reaction)
+	VALUES ($1, $2, $3)
+	ON CONFLICT DO NOTHING`,
+
+	deletePostReaction: `DELETE FROM post_reaction
+	WHERE post_id = $1 AND user_nickname = $2 AND reaction = $3`,
+
+	updatePostReactions: `UPDATE post
+	SET reactions = CASE
+		WHEN COALESCE((reactions->>$2::TEXT)::INTEGER, 0) + $1::INTEGER > 0
+			THEN jsonb_set(reactions, ARRAY[$2::TEXT], to_jsonb(COALESCE((reactions->>$2::TEXT)::INTEGER, 0) + $1::INTEGER))
+		ELSE reactions - $2::TEXT
+	END
+	WHERE id = $3
+	RETURNING id, message, created, is_edited, user_nickname, thread_id, forum_slug, parent, votes, reactions, mentions, COALESCE(message_html, '')`,
+
+	countThreadVotes: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM vote WHERE thread_id = $1 LIMIT $2) AS capped;`,
+
+	countUserVotes: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM vote WHERE user_nickname = $1 LIMIT $2) AS capped;`,
 }
 
 func NewVoteRepo(conn *pgx.ConnPool) *Vote {
@@ -35,7 +151,7 @@ type Vote struct {
 	conn *pgx.ConnPool
 }
 
-func (v *Vote) CreateVote(data *vote.Vote, slugOrId string) (*thread.Thread, error) {
+func (v *Vote) CreateVote(data *vote.Vote, slugOrId string, emit func(*thread.Thread) event.Typed) (*thread.Thread, error) {
 	tx, err := v.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -75,10 +191,315 @@ func (v *Vote) CreateVote(data *vote.Vote, slugOrId string) (*thread.Thread, err
 	var received thread.Thread
 
 	if err := tx.QueryRow(updateThreadVotes, data.Rating, threadID).
-		Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes); err != nil {
+		Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes, &received.Tags, &received.Rendered); err != nil {
 		return nil, err
 	}
 
+	if data.Rating != 0 {
+		if err := changeReputation(tx, data.Rating, received.UserNickname, received.ForumSlug); err != nil {
+			return nil, err
+		}
+
+		if err := recordEvent(tx, emit(&received)); err != nil {
+			return nil, err
+		}
+	}
+
 	tx.Commit()
 	return &received, nil
 }
+
+func (v *Vote) DeleteVote(data *vote.Vote, slugOrId string, emit func(*thread.Thread) event.Typed) (*thread.Thread, error) {
+	tx, err := v.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var threadID uint64
+
+	if err := tx.QueryRow(getUserByNickname, data.UserNickname).
+		Scan(&data.UserNickname); err != nil {
+		return nil, err
+	}
+
+	var slug *string
+	if err := tx.QueryRow(checkThreadByIdOrSlug, slugOrId).
+		Scan(&threadID, &slug); err != nil {
+		return nil, err
+	}
+
+	// Retracting a vote reverts its contribution to the thread rating
+	if err := tx.QueryRow(deleteVote, data.UserNickname, threadID).Scan(&data.Voice); err != nil {
+		return nil, err
+	}
+	data.Rating = 1
+	if data.Voice {
+		data.Rating = -1
+	}
+
+	var received thread.Thread
+
+	if err := tx.QueryRow(updateThreadVotes, data.Rating, threadID).
+		Scan(&received.ID, &received.Slug, &received.Title, &received.Message, &received.ForumSlug, &received.UserNickname, &received.Created, &received.Votes, &received.Tags, &received.Rendered); err != nil {
+		return nil, err
+	}
+
+	if data.Rating != 0 {
+		if err := changeReputation(tx, data.Rating, received.UserNickname, received.ForumSlug); err != nil {
+			return nil, err
+		}
+
+		if err := recordEvent(tx, emit(&received)); err != nil {
+			return nil, err
+		}
+	}
+
+	tx.Commit()
+	return &received, nil
+}
+
+func (v *Vote) GetThreadVotes(slugOrId string, limit *int, since *string, orderDesc bool) (*vote.Votes, error) {
+	var threadID uint64
+	var slug *string
+	if err := v.conn.QueryRow(checkThreadByIdOrSlug, slugOrId).
+		Scan(&threadID, &slug); err != nil {
+		return nil, err
+	}
+
+	votes := make(vote.Votes, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = v.conn.Query(getThreadVotesLimitDesc, threadID, limit)
+		} else {
+			rows, err = v.conn.Query(getThreadVotesLimit, threadID, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = v.conn.Query(getThreadVotesLimitSinceDesc, threadID, limit, since)
+		} else {
+			rows, err = v.conn.Query(getThreadVotesLimitSince, threadID, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row vote.Vote
+		rows.Scan(&row.Rating, &row.UserNickname, &row.ThreadID)
+		votes = append(votes, row)
+	}
+
+	return &votes, nil
+}
+
+func (v *Vote) GetUserVotes(nickname string, limit *int, since *string, orderDesc bool) (*vote.Votes, error) {
+	if err := v.conn.QueryRow(getUserByNickname, nickname).Scan(&nickname); err != nil {
+		return nil, err
+	}
+
+	votes := make(vote.Votes, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = v.conn.Query(getUserVotesLimitDesc, nickname, limit)
+		} else {
+			rows, err = v.conn.Query(getUserVotesLimit, nickname, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = v.conn.Query(getUserVotesLimitSinceDesc, nickname, limit, since)
+		} else {
+			rows, err = v.conn.Query(getUserVotesLimitSince, nickname, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row vote.Vote
+		rows.Scan(&row.Rating, &row.UserNickname, &row.ThreadID)
+		votes = append(votes, row)
+	}
+
+	return &votes, nil
+}
+
+func (v *Vote) CreatePostVote(data *vote.Vote, id string, emit func(*post.Post) event.Typed) (*post.Post, error) {
+	tx, err := v.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var postID uint64
+
+	if err := tx.QueryRow(getUserByNickname, data.UserNickname).
+		Scan(&data.UserNickname); err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(checkPostById, id).Scan(&postID); err != nil {
+		return nil, err
+	}
+
+	var inserted bool
+	switch err := tx.QueryRow(createPostVote, data.Voice, data.UserNickname, postID).Scan(&inserted); {
+	case err == pgx.ErrNoRows:
+		data.Rating = 0
+	case err != nil:
+		return nil, err
+	case !inserted:
+		data.Rating *= 2
+	}
+
+	var received post.Post
+	if err := tx.QueryRow(updatePostVotes, data.Rating, postID).
+		Scan(&received.ID, &received.Message, &received.Created, &received.IsEdited, &received.UserNickname, &received.ThreadID, &received.ForumSlug, &received.Parent, &received.Votes, &received.Reactions, &received.Mentions, &received.Rendered); err != nil {
+		return nil, err
+	}
+
+	if data.Rating != 0 {
+		if err := changeReputation(tx, data.Rating, received.UserNickname, received.ForumSlug); err != nil {
+			return nil, err
+		}
+
+		if err := recordEvent(tx, emit(&received)); err != nil {
+			return nil, err
+		}
+	}
+
+	tx.Commit()
+	return &received, nil
+}
+
+func (v *Vote) DeletePostVote(data *vote.Vote, id string, emit func(*post.Post) event.Typed) (*post.Post, error) {
+	tx, err := v.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var postID uint64
+
+	if err := tx.QueryRow(getUserByNickname, data.UserNickname).
+		Scan(&data.UserNickname); err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(checkPostById, id).Scan(&postID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(deletePostVote, data.UserNickname, postID).Scan(&data.Voice); err != nil {
+		return nil, err
+	}
+	data.Rating = 1
+	if data.Voice {
+		data.Rating = -1
+	}
+
+	var received post.Post
+	if err := tx.QueryRow(updatePostVotes, data.Rating, postID).
+		Scan(&received.ID, &received.Message, &received.Created, &received.IsEdited, &received.UserNickname, &received.ThreadID, &received.ForumSlug, &received.Parent, &received.Votes, &received.Reactions, &received.Mentions, &received.Rendered); err != nil {
+		return nil, err
+	}
+
+	if err := changeReputation(tx, data.Rating, received.UserNickname, received.ForumSlug); err != nil {
+		return nil, err
+	}
+
+	if err := recordEvent(tx, emit(&received)); err != nil {
+		return nil, err
+	}
+
+	tx.Commit()
+	return &received, nil
+}
+
+func (v *Vote) CreateReaction(data *vote.Reaction, id string, emit func(*post.Post) event.Typed) (*post.Post, error) {
+	return v.changeReaction(data, id, createPostReaction, 1, emit)
+}
+
+func (v *Vote) DeleteReaction(data *vote.Reaction, id string, emit func(*post.Post) event.Typed) (*post.Post, error) {
+	return v.changeReaction(data, id, deletePostReaction, -1, emit)
+}
+
+func (v *Vote) changeReaction(data *vote.Reaction, id string, query string, delta int, emit func(*post.Post) event.Typed) (*post.Post, error) {
+	tx, err := v.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var postID uint64
+
+	if err := tx.QueryRow(getUserByNickname, data.UserNickname).
+		Scan(&data.UserNickname); err != nil {
+		return nil, err
+	}
+
+	if err := tx.QueryRow(checkPostById, id).Scan(&postID); err != nil {
+		return nil, err
+	}
+
+	tag, err := tx.Exec(query, postID, data.UserNickname, data.Reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repeated or missing reactions leave the counters untouched
+	if tag.RowsAffected() == 0 {
+		delta = 0
+	}
+
+	var received post.Post
+	if err := tx.QueryRow(updatePostReactions, delta, data.Reaction, postID).
+		Scan(&received.ID, &received.Message, &received.Created, &received.IsEdited, &received.UserNickname, &received.ThreadID, &received.ForumSlug, &received.Parent, &received.Votes, &received.Reactions, &received.Mentions, &received.Rendered); err != nil {
+		return nil, err
+	}
+
+	if delta != 0 {
+		if err := recordEvent(tx, emit(&received)); err != nil {
+			return nil, err
+		}
+	}
+
+	tx.Commit()
+	return &received, nil
+}
+
+func (v *Vote) CountThreadVotes(slugOrId string) (*page.Total, error) {
+	var threadID uint64
+	var slug *string
+	if err := v.conn.QueryRow(checkThreadByIdOrSlug, slugOrId).
+		Scan(&threadID, &slug); err != nil {
+		return nil, err
+	}
+
+	return countCapped(v.conn, countThreadVotes, threadID)
+}
+
+func (v *Vote) CountUserVotes(nickname string) (*page.Total, error) {
+	return countCapped(v.conn, countUserVotes, nickname)
+}
+
+// changeReputation adds the rating of a vote on authored content to the author reputation,
+// both overall and in the forum the content belongs to
+func changeReputation(tx *pgx.Tx, rating int, nickname, forumSlug string) error {
+	if _, err := tx.Exec(updateUserReputation, rating, nickname); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(updateForumUserReputation, rating, forumSlug, nickname)
+	return err
+}
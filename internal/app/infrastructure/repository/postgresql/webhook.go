@@ -0,0 +1,329 @@
+package postgresql
+
+import (
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/webhook"
+	"github.com/jackc/pgx"
+)
+
+const (
+	createWebhook               = "createWebhook"
+	getWebhooks                 = "getWebhooks"
+	getWebhook                  = "getWebhook"
+	updateWebhook               = "updateWebhook"
+	deleteWebhook               = "deleteWebhook"
+	deleteWebhookDeliveries     = "deleteWebhookDeliveries"
+	getDeliveriesLimit          = "getDeliveriesLimit"
+	getDeliveriesLimitDesc      = "getDeliveriesLimitDesc"
+	getDeliveriesLimitSince     = "getDeliveriesLimitSince"
+	getDeliveriesLimitSinceDesc = "getDeliveriesLimitSinceDesc"
+	countDeliveries             = "countDeliveries"
+	enqueueDeliveries           = "enqueueDeliveries"
+	getDueDeliveries            = "getDueDeliveries"
+	completeDelivery            = "completeDelivery"
+	failDelivery                = "failDelivery"
+	resetWebhookFailures        = "resetWebhookFailures"
+	countWebhookFailure         = "countWebhookFailure"
+)
+
+var webhookQueries = map[string]string{
+	createWebhook: `INSERT INTO webhook (forum_slug, url, secret, events)
+	VALUES ($1, $2, $3, COALESCE($4::TEXT[], '{}'))
+	RETURNING id, forum_slug, url, secret, events, active, failures, created;`,
+
+	getWebhooks: `SELECT id, forum_slug, url, events, active, failures, created
+	FROM webhook
+	WHERE forum_slug = $1
+	ORDER BY id;`,
+
+	getWebhook: `SELECT id, forum_slug, url, events, active, failures, created
+	FROM webhook
+	WHERE id = $1 AND forum_slug = $2;`,
+
+	// Re-enabling a webhook gives it a fresh failure budget
+	updateWebhook: `UPDATE webhook
+	SET url = COALESCE($3, url),
+		secret = COALESCE($4, secret),
+		events = COALESCE($5::TEXT[], events),
+		active = COALESCE($6, active),
+		failures = CASE WHEN $6::BOOLEAN THEN 0 ELSE failures END
+	WHERE id = $1 AND forum_slug = $2
+	RETURNING id, forum_slug, url, events, active, failures, created;`,
+
+	deleteWebhook: `DELETE FROM webhook
+	WHERE id = $1 AND forum_slug = $2
+	RETURNING id;`,
+
+	deleteWebhookDeliveries: `DELETE FROM webhook_delivery
+	WHERE webhook_id = $1;`,
+
+	getDeliveriesLimit: `SELECT id, webhook_id, event_id, event_type, state, attempts, status, error,
+		CASE WHEN state = 'pending' THEN next_attempt END, created, updated
+	FROM webhook_delivery
+	WHERE webhook_id = $1
+	ORDER BY id
+	LIMIT $2;`,
+
+	getDeliveriesLimitDesc: `SELECT id, webhook_id, event_id, event_type, state, attempts, status, error,
+		CASE WHEN state = 'pending' THEN next_attempt END, created, updated
+	FROM webhook_delivery
+	WHERE webhook_id = $1
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	getDeliveriesLimitSince: `SELECT id, webhook_id, event_id, event_type, state, attempts, status, error,
+		CASE WHEN state = 'pending' THEN next_attempt END, created, updated
+	FROM webhook_delivery
+	WHERE webhook_id = $1 AND id > $3::TEXT::BIGINT
+	ORDER BY id
+	LIMIT $2;`,
+
+	getDeliveriesLimitSinceDesc: `SELECT id, webhook_id, event_id, event_type, state, attempts, status, error,
+		CASE WHEN state = 'pending' THEN next_attempt END, created, updated
+	FROM webhook_delivery
+	WHERE webhook_id = $1 AND id < $3::TEXT::BIGINT
+	ORDER BY id DESC
+	LIMIT $2;`,
+
+	countDeliveries: `SELECT COUNT(*)
+	FROM (SELECT 1 FROM webhook_delivery WHERE webhook_id = $1 LIMIT $2) AS capped;`,
+
+	// Redelivered outbox events hit the unique index and are not queued twice
+	enqueueDeliveries: `INSERT INTO webhook_delivery (webhook_id, event_id, event_type, payload)
+	SELECT id, $1, $2, $3::TEXT::JSONB
+	FROM webhook
+	WHERE forum_slug = $4 AND active AND (cardinality(events) = 0 OR $2 = ANY(events))
+	ON CONFLICT (webhook_id, event_id) DO NOTHING;`,
+
+	getDueDeliveries: `SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.attempts, w.url, w.secret, d.payload::TEXT
+	FROM webhook_delivery AS d
+	JOIN webhook AS w ON (w.id = d.webhook_id)
+	WHERE d.state = 'pending' AND d.next_attempt <= NOW() AND w.active
+	ORDER BY d.next_attempt, d.id
+	LIMIT $1;`,
+
+	completeDelivery: `UPDATE webhook_delivery
+	SET state = 'delivered', attempts = attempts + 1, status = $2, error = NULL, updated = NOW()
+	WHERE id = $1;`,
+
+	failDelivery: `UPDATE webhook_delivery
+	SET state = CASE WHEN $4::TIMESTAMPTZ IS NULL THEN 'failed' ELSE 'pending' END,
+		attempts = attempts + 1,
+		status = $2,
+		error = $3,
+		next_attempt = COALESCE($4::TIMESTAMPTZ, next_attempt),
+		updated = NOW()
+	WHERE id = $1;`,
+
+	resetWebhookFailures: `UPDATE webhook
+	SET failures = 0
+	WHERE id = $1;`,
+
+	countWebhookFailure: `UPDATE webhook
+	SET failures = failures + 1,
+		active = active AND failures + 1 < $2
+	WHERE id = $1;`,
+}
+
+func NewWebhookRepo(conn *pgx.ConnPool) *Webhook {
+	return &Webhook{
+		conn: conn,
+	}
+}
+
+type Webhook struct {
+	conn *pgx.ConnPool
+}
+
+func (w *Webhook) CreateWebhook(slug string, data *webhook.Create) (*webhook.Webhook, error) {
+	if err := w.conn.QueryRow(getForumSlugBySlug, slug).Scan(&slug); err != nil {
+		return nil, err
+	}
+
+	created := &webhook.Webhook{}
+	if err := w.conn.QueryRow(createWebhook, slug, data.URL, data.Secret, data.Events).
+		Scan(&created.ID, &created.ForumSlug, &created.URL, &created.Secret, &created.Events, &created.Active, &created.Failures, &created.Created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (w *Webhook) GetWebhooks(slug string) (*webhook.Webhooks, error) {
+	if err := w.conn.QueryRow(getForumSlugBySlug, slug).Scan(&slug); err != nil {
+		return nil, err
+	}
+
+	rows, err := w.conn.Query(getWebhooks, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make(webhook.Webhooks, 0)
+	for rows.Next() {
+		var row webhook.Webhook
+		rows.Scan(&row.ID, &row.ForumSlug, &row.URL, &row.Events, &row.Active, &row.Failures, &row.Created)
+		webhooks = append(webhooks, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &webhooks, nil
+}
+
+func (w *Webhook) GetWebhook(slug string, id uint64) (*webhook.Webhook, error) {
+	received := &webhook.Webhook{}
+	if err := w.conn.QueryRow(getWebhook, id, slug).
+		Scan(&received.ID, &received.ForumSlug, &received.URL, &received.Events, &received.Active, &received.Failures, &received.Created); err != nil {
+		return nil, err
+	}
+
+	return received, nil
+}
+
+func (w *Webhook) UpdateWebhook(slug string, id uint64, data *webhook.Update) (*webhook.Webhook, error) {
+	updated := &webhook.Webhook{}
+	if err := w.conn.QueryRow(updateWebhook, id, slug, data.URL, data.Secret, data.Events, data.Active).
+		Scan(&updated.ID, &updated.ForumSlug, &updated.URL, &updated.Events, &updated.Active, &updated.Failures, &updated.Created); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (w *Webhook) DeleteWebhook(slug string, id uint64) error {
+	tx, err := w.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(deleteWebhook, id, slug).Scan(&id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(deleteWebhookDeliveries, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (w *Webhook) GetDeliveries(slug string, id uint64, limit *int, since *string, orderDesc bool) (*webhook.Deliveries, error) {
+	if _, err := w.GetWebhook(slug, id); err != nil {
+		return nil, err
+	}
+
+	deliveries := make(webhook.Deliveries, 0)
+	var err error
+	var rows *pgx.Rows
+
+	if since == nil {
+		if orderDesc {
+			rows, err = w.conn.Query(getDeliveriesLimitDesc, id, limit)
+		} else {
+			rows, err = w.conn.Query(getDeliveriesLimit, id, limit)
+		}
+	} else {
+		if orderDesc {
+			rows, err = w.conn.Query(getDeliveriesLimitSinceDesc, id, limit, since)
+		} else {
+			rows, err = w.conn.Query(getDeliveriesLimitSince, id, limit, since)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var row webhook.Delivery
+		rows.Scan(&row.ID, &row.WebhookID, &row.EventID, &row.EventType, &row.State, &row.Attempts, &row.Status, &row.Error, &row.NextAttempt, &row.Created, &row.Updated)
+		deliveries = append(deliveries, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &deliveries, nil
+}
+
+func (w *Webhook) CountDeliveries(id uint64) (*page.Total, error) {
+	return countCapped(w.conn, countDeliveries, id)
+}
+
+func (w *Webhook) EnqueueDeliveries(e *event.Event) error {
+	payload, err := e.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.conn.Exec(enqueueDeliveries, e.ID, e.Type, string(payload), e.ForumSlug)
+	return err
+}
+
+func (w *Webhook) GetDueJobs(limit int) ([]webhook.Job, error) {
+	rows, err := w.conn.Query(getDueDeliveries, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]webhook.Job, 0)
+	for rows.Next() {
+		var row webhook.Job
+		var payload string
+		rows.Scan(&row.DeliveryID, &row.WebhookID, &row.EventID, &row.EventType, &row.Attempts, &row.URL, &row.Secret, &payload)
+		row.Payload = []byte(payload)
+		jobs = append(jobs, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (w *Webhook) CompleteDelivery(job *webhook.Job, status int) error {
+	tx, err := w.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(completeDelivery, job.DeliveryID, status); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(resetWebhookFailures, job.WebhookID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FailDelivery records a failed attempt, a nil retryAt gives the delivery up.
+// The webhook is disabled once it has failed disableAfter times in a row.
+func (w *Webhook) FailDelivery(job *webhook.Job, status *int, reason string, retryAt *time.Time, disableAfter int) error {
+	tx, err := w.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(failDelivery, job.DeliveryID, status, reason, retryAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(countWebhookFailure, job.WebhookID, disableAfter); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/webhook"
+	"github.com/valyala/fasthttp"
+)
+
+var ErrPrivateAddress = errors.New("webhook endpoint resolves to a loopback or private address")
+
+// PublicDial connects to the first public address a host resolves to. The address is
+// checked and dialed as is, so a name can't switch to a private one in between.
+func PublicDial(timeout time.Duration) fasthttp.DialFunc {
+	return func(addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := net.LookupIP(host)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range ips {
+			if webhook.PublicIP(ip) {
+				return net.DialTimeout("tcp", net.JoinHostPort(ip.String(), port), timeout)
+			}
+		}
+		return nil, ErrPrivateAddress
+	}
+}
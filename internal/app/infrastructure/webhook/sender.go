@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Doer performs an outgoing request, *fasthttp.Client satisfies it
+type Doer interface {
+	DoTimeout(req *fasthttp.Request, resp *fasthttp.Response, timeout time.Duration) error
+}
+
+func NewSender(client Doer, timeout time.Duration) *Sender {
+	return &Sender{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// Sender posts webhook deliveries over HTTP
+type Sender struct {
+	client  Doer
+	timeout time.Duration
+}
+
+func (s *Sender) Send(url string, headers map[string]string, body []byte) (int, error) {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(url)
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.SetBody(body)
+
+	// Response bodies are not kept, only the status decides the outcome
+	resp.SkipBody = true
+	if err := s.client.DoTimeout(req, resp, s.timeout); err != nil {
+		return 0, err
+	}
+
+	return resp.StatusCode(), nil
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+func NewBanInteractor(repo repository.Ban, moderation repository.Moderation, validator *Validator, identities *Identities, globalModerators []string) *BanInteractor {
+	global := make(map[string]bool, len(globalModerators))
+	for _, nickname := range globalModerators {
+		global[strings.ToLower(nickname)] = true
+	}
+
+	return &BanInteractor{
+		repository: repo,
+		moderation: moderation,
+		validator:  validator,
+		identities: identities,
+		global:     global,
+	}
+}
+
+// BanInteractor manages bans and enforces them on writes.
+// Global moderators ban users everywhere, forum owners and moderators ban them from their forum.
+type BanInteractor struct {
+	repository repository.Ban
+	moderation repository.Moderation
+	validator  *Validator
+	identities *Identities
+	global     map[string]bool
+}
+
+// GetBans returns the active bans of a user that the holder of the token is allowed to see
+func (i *BanInteractor) GetBans(nickname, token string) (*ban.Bans, error) {
+	viewer, err := i.identities.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	bans, err := i.repository.GetBans(nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.global[strings.ToLower(viewer)] {
+		return bans, nil
+	}
+
+	visible := make(ban.Bans, 0, len(*bans))
+	roles := make(map[string]bool)
+	for _, received := range *bans {
+		if received.ForumSlug == nil {
+			continue
+		}
+
+		slug := strings.ToLower(*received.ForumSlug)
+		moderates, ok := roles[slug]
+		if !ok {
+			role, err := i.moderation.GetRole(slug, viewer)
+			if err != nil {
+				return nil, err
+			}
+			moderates = role != ""
+			roles[slug] = moderates
+		}
+
+		if moderates {
+			visible = append(visible, received)
+		}
+	}
+
+	return &visible, nil
+}
+
+// CreateBan places a ban on behalf of the moderator the token was issued for
+func (i *BanInteractor) CreateBan(nickname string, data *ban.Create, token string) (*ban.Ban, error) {
+	if err := i.validator.Ban(data); err != nil {
+		return nil, err
+	}
+
+	moderator, err := i.identities.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(moderator, data.Moderator) {
+		return nil, errUnauthorized
+	}
+
+	if err := i.authorize(data.ForumSlug, data.Moderator); err != nil {
+		return nil, err
+	}
+
+	return i.repository.CreateBan(nickname, data)
+}
+
+func (i *BanInteractor) LiftBan(nickname string, id uint64, token string) error {
+	moderator, err := i.identities.Verify(token)
+	if err != nil {
+		return err
+	}
+
+	received, err := i.repository.GetBan(nickname, id)
+	if err != nil {
+		return err
+	}
+
+	if err := i.authorize(received.ForumSlug, moderator); err != nil {
+		return err
+	}
+
+	return i.repository.DeleteBan(id)
+}
+
+// CheckForum fails with a ban error when any of the users is banned from the forum
+func (i *BanInteractor) CheckForum(nicknames []string, forumSlug string) error {
+	return refuse(i.repository.FindActiveBan(nicknames, forumSlug))
+}
+
+// CheckThread is CheckForum for the forum of a thread
+func (i *BanInteractor) CheckThread(nicknames []string, slugOrId string) error {
+	return refuse(i.repository.FindActiveThreadBan(nicknames, slugOrId, false))
+}
+
+// CheckPost is CheckForum for the forum of a post
+func (i *BanInteractor) CheckPost(nicknames []string, id string) error {
+	return refuse(i.repository.FindActivePostBan(nicknames, id, false))
+}
+
+// CheckThreadEdit keeps the author of a thread and its editor, when given, from changing it while banned
+func (i *BanInteractor) CheckThreadEdit(editor *string, slugOrId string) error {
+	return refuse(i.repository.FindActiveThreadBan(editors(editor), slugOrId, true))
+}
+
+// CheckPostEdit is CheckThreadEdit for a post
+func (i *BanInteractor) CheckPostEdit(editor *string, id string) error {
+	return refuse(i.repository.FindActivePostBan(editors(editor), id, true))
+}
+
+// authorize lets global moderators manage every ban and forum moderators the bans of their forum
+func (i *BanInteractor) authorize(forumSlug *string, moderator string) error {
+	if forumSlug != nil {
+		role, err := i.moderation.GetRole(*forumSlug, moderator)
+		if err != nil {
+			return err
+		}
+
+		if role != "" {
+			return nil
+		}
+	}
+
+	if i.global[strings.ToLower(moderator)] {
+		return nil
+	}
+
+	return errForbidden
+}
+
+func editors(editor *string) []string {
+	if editor == nil {
+		return []string{}
+	}
+	return []string{*editor}
+}
+
+func refuse(found *ban.Ban, err error) error {
+	if err != nil || found == nil {
+		return err
+	}
+
+	return &ban.Error{
+		Description: "User " + found.UserNickname + " is banned",
+		Ban:         found,
+	}
+}
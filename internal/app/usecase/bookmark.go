@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/bookmark"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+func NewBookmarkInteractor(repo repository.Bookmark, validator *Validator) *BookmarkInteractor {
+	return &BookmarkInteractor{
+		repository: repo,
+		validator:  validator,
+	}
+}
+
+type BookmarkInteractor struct {
+	repository repository.Bookmark
+	validator  *Validator
+}
+
+func (i *BookmarkInteractor) CreateBookmark(nickname string, data *bookmark.Create) (*bookmark.Bookmark, error) {
+	data.Folder = strings.TrimSpace(data.Folder)
+
+	if err := i.validator.Bookmark(data); err != nil {
+		return nil, err
+	}
+
+	return i.repository.CreateBookmark(nickname, data)
+}
+
+func (i *BookmarkInteractor) GetBookmarks(nickname string, folder *string, limit *int, since *string, orderDesc bool) (*bookmark.Bookmarks, error) {
+	return i.repository.GetBookmarks(nickname, folder, limit, since, orderDesc)
+}
+
+func (i *BookmarkInteractor) CountBookmarks(nickname string, folder *string) (*page.Total, error) {
+	return i.repository.CountBookmarks(nickname, folder)
+}
+
+func (i *BookmarkInteractor) GetFolders(nickname string) (*bookmark.Folders, error) {
+	return i.repository.GetFolders(nickname)
+}
+
+func (i *BookmarkInteractor) DeleteBookmark(nickname string, id uint64) error {
+	return i.repository.DeleteBookmark(nickname, id)
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/conversation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+func NewConversationInteractor(repo repository.Conversation, validator *Validator) *ConversationInteractor {
+	return &ConversationInteractor{
+		repository: repo,
+		validator:  validator,
+	}
+}
+
+type ConversationInteractor struct {
+	repository repository.Conversation
+	validator  *Validator
+}
+
+// Send continues a conversation of the user or starts a new one with the recipients
+func (i *ConversationInteractor) Send(nickname string, data *conversation.Send) (*conversation.Message, error) {
+	if data.ConversationID == nil {
+		data.Recipients = otherParticipants(nickname, data.Recipients)
+	}
+
+	if err := i.validator.Send(data); err != nil {
+		return nil, err
+	}
+
+	if data.ConversationID != nil {
+		return i.repository.SendMessage(nickname, *data.ConversationID, data.Message)
+	}
+
+	return i.repository.StartConversation(nickname, append(data.Recipients, nickname), data.Subject, data.Message)
+}
+
+func (i *ConversationInteractor) GetConversations(nickname string, limit *int, since *string, orderDesc bool, unread bool) (*conversation.Conversations, error) {
+	return i.repository.GetConversations(nickname, limit, since, orderDesc, unread)
+}
+
+func (i *ConversationInteractor) CountConversations(nickname string, unread bool) (*page.Total, error) {
+	return i.repository.CountConversations(nickname, unread)
+}
+
+func (i *ConversationInteractor) GetMessages(nickname string, id uint64, limit *int, since *string, orderDesc bool) (*conversation.Messages, error) {
+	return i.repository.GetMessages(nickname, id, limit, since, orderDesc)
+}
+
+func (i *ConversationInteractor) CountMessages(id uint64) (*page.Total, error) {
+	return i.repository.CountMessages(id)
+}
+
+func (i *ConversationInteractor) MarkRead(nickname string, id uint64) (*conversation.Unread, error) {
+	return i.repository.MarkRead(nickname, id)
+}
+
+// otherParticipants drops the sender and repeated recipients, nicknames compare case-insensitively
+func otherParticipants(nickname string, recipients []string) []string {
+	seen := map[string]bool{strings.ToLower(nickname): true}
+	others := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		key := strings.ToLower(recipient)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		others = append(others, recipient)
+	}
+
+	return others
+}
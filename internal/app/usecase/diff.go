@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+)
+
+const (
+	diffEqual  = "="
+	diffInsert = "+"
+	diffDelete = "-"
+
+	// diffMaxEdits bounds the work on very different texts, past it the changed middle
+	// is reported as deleted and inserted as a whole
+	diffMaxEdits = 1000
+)
+
+// diffLines builds a shortest line based diff with the linear space variant of Myers' algorithm
+func diffLines(from, to string) []post.DiffLine {
+	a := strings.Split(from, "\n")
+	b := strings.Split(to, "\n")
+
+	size := len(a) + len(b) + 2
+	d := &differ{
+		a:       a,
+		b:       b,
+		lines:   make([]post.DiffLine, 0, len(a)+len(b)),
+		forward: make([]int, 2*size+1),
+		reverse: make([]int, 2*size+1),
+	}
+	d.diff(0, len(a), 0, len(b))
+
+	return d.lines
+}
+
+type differ struct {
+	a, b  []string
+	lines []post.DiffLine
+
+	// Furthest reaching x per diagonal of both searches, offset so negative diagonals fit
+	forward, reverse []int
+}
+
+// diff appends the edits turning a[a0:a1] into b[b0:b1]
+func (d *differ) diff(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.a[a0] == d.b[b0] {
+		d.lines = append(d.lines, post.DiffLine{Op: diffEqual, Text: d.a[a0]})
+		a0++
+		b0++
+	}
+
+	suffix := 0
+	for a0 < a1-suffix && b0 < b1-suffix && d.a[a1-suffix-1] == d.b[b1-suffix-1] {
+		suffix++
+	}
+	a1 -= suffix
+	b1 -= suffix
+
+	switch {
+	case a0 == a1:
+		d.emit(diffInsert, d.b[b0:b1])
+	case b0 == b1:
+		d.emit(diffDelete, d.a[a0:a1])
+	default:
+		x, y, u, v, ok := d.middleSnake(a0, a1, b0, b1)
+		if !ok {
+			d.emit(diffDelete, d.a[a0:a1])
+			d.emit(diffInsert, d.b[b0:b1])
+			break
+		}
+
+		d.diff(a0, x, b0, y)
+		d.emit(diffEqual, d.a[x:u])
+		d.diff(u, a1, v, b1)
+	}
+
+	d.emit(diffEqual, d.a[a1:a1+suffix])
+}
+
+func (d *differ) emit(op string, texts []string) {
+	for _, text := range texts {
+		d.lines = append(d.lines, post.DiffLine{Op: op, Text: text})
+	}
+}
+
+// middleSnake finds the snake halfway along a shortest edit path, searching from both ends
+// until they overlap. It gives up once the path would exceed diffMaxEdits.
+func (d *differ) middleSnake(a0, a1, b0, b1 int) (x, y, u, v int, ok bool) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta%2 != 0
+	offset := len(d.forward) / 2
+
+	d.forward[offset+1] = 0
+	d.reverse[offset+1] = 0
+
+	// Both searches advance together, so each covers half of the edits
+	for depth := 0; depth <= (n+m+1)/2 && depth <= diffMaxEdits/2; depth++ {
+		for k := -depth; k <= depth; k += 2 {
+			var startX int
+			if k == -depth || (k != depth && d.forward[offset+k-1] < d.forward[offset+k+1]) {
+				startX = d.forward[offset+k+1]
+			} else {
+				startX = d.forward[offset+k-1] + 1
+			}
+			startY := startX - k
+
+			endX, endY := startX, startY
+			for endX < n && endY < m && d.a[a0+endX] == d.b[b0+endY] {
+				endX++
+				endY++
+			}
+			d.forward[offset+k] = endX
+
+			if reverseK := delta - k; odd && reverseK >= -(depth-1) && reverseK <= depth-1 && endX+d.reverse[offset+reverseK] >= n {
+				return a0 + startX, b0 + startY, a0 + endX, b0 + endY, true
+			}
+		}
+
+		// The reverse search runs on the texts read from their ends
+		for k := -depth; k <= depth; k += 2 {
+			var startX int
+			if k == -depth || (k != depth && d.reverse[offset+k-1] < d.reverse[offset+k+1]) {
+				startX = d.reverse[offset+k+1]
+			} else {
+				startX = d.reverse[offset+k-1] + 1
+			}
+			startY := startX - k
+
+			endX, endY := startX, startY
+			for endX < n && endY < m && d.a[a1-1-endX] == d.b[b1-1-endY] {
+				endX++
+				endY++
+			}
+			d.reverse[offset+k] = endX
+
+			if forwardK := delta - k; !odd && forwardK >= -depth && forwardK <= depth && endX+d.forward[offset+forwardK] >= n {
+				return a1 - endX, b1 - endY, a1 - startX, b1 - startY, true
+			}
+		}
+	}
+
+	return 0, 0, 0, 0, false
+}
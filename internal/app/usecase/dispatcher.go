@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+const (
+	// dispatchLease is how long claimed events stay with a dispatcher before another may take them
+	dispatchLease = 30 * time.Second
+
+	// dispatchAttempts is the number of failed rounds after which an event is dead lettered
+	dispatchAttempts = 10
+)
+
+// Subscriber handles dispatched events. Returning an error leaves the event pending for
+// that subscriber alone, subscribers that handled it already don't see it again.
+type Subscriber interface {
+	Handle(e *event.Event) error
+}
+
+func NewDispatcher(repo repository.Outbox, interval time.Duration, batch int) *Dispatcher {
+	return &Dispatcher{
+		repository: repo,
+		interval:   interval,
+		batch:      batch,
+	}
+}
+
+// Dispatcher polls the outbox written by repositories and delivers events at least once.
+// Events reach each subscriber in order unless it fails on one, which is then retried with
+// a growing delay and dead lettered after dispatchAttempts rounds. Several dispatchers may
+// share the outbox, each claims its own batches.
+type Dispatcher struct {
+	repository  repository.Outbox
+	interval    time.Duration
+	batch       int
+	subscribers []subscriber
+}
+
+type subscriber struct {
+	name string
+	Subscriber
+}
+
+// Subscribe registers a subscriber under a name that is stable across restarts,
+// progress on every event is tracked by it. It must be called before Run.
+func (d *Dispatcher) Subscribe(name string, s Subscriber) {
+	d.subscribers = append(d.subscribers, subscriber{name: name, Subscriber: s})
+}
+
+func (d *Dispatcher) Run() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		// A full batch means more events are pending, keep going without waiting for the next tick
+		for {
+			claimed, err := d.Dispatch()
+			if err != nil {
+				log.Println("[Failed] dispatching events. Error:", err)
+				break
+			}
+			if claimed < d.batch {
+				break
+			}
+		}
+	}
+}
+
+// Dispatch delivers a single batch of claimed events and returns how many were claimed
+func (d *Dispatcher) Dispatch() (int, error) {
+	events, err := d.repository.ClaimEvents(d.batch, dispatchLease)
+	if err != nil {
+		return 0, err
+	}
+
+	done := make([]uint64, 0, len(*events))
+	for i := range *events {
+		e := &(*events)[i]
+
+		finished, err := d.deliver(e)
+		if err != nil {
+			return 0, err
+		}
+		if finished {
+			done = append(done, e.ID)
+		}
+	}
+
+	if len(done) != 0 {
+		if err := d.repository.DeleteEvents(done); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(*events), nil
+}
+
+// deliver hands the event to the subscribers that haven't handled it yet and tells whether
+// the event is finished, either handled by all of them or dead lettered for the failing ones
+func (d *Dispatcher) deliver(e *event.Event) (bool, error) {
+	handled := make(map[string]bool, len(e.Handled))
+	for _, name := range e.Handled {
+		handled[name] = true
+	}
+
+	failures := make(map[string]error)
+	for _, s := range d.subscribers {
+		if handled[s.name] {
+			continue
+		}
+
+		if err := s.Handle(e); err != nil {
+			failures[s.name] = err
+			continue
+		}
+		e.Handled = append(e.Handled, s.name)
+	}
+
+	if len(failures) == 0 {
+		return true, nil
+	}
+
+	attempts, err := d.repository.ReleaseEvent(e.ID, e.Handled, time.Duration(e.Attempts+1)*d.interval)
+	if err != nil {
+		return false, err
+	}
+
+	if attempts < dispatchAttempts {
+		return false, nil
+	}
+
+	for name, failure := range failures {
+		log.Println("[Failed] dead lettering event", e.ID, "for", name, "Error:", failure)
+		if err := d.repository.DeadLetter(e.ID, name, failure.Error()); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -1,19 +1,30 @@
 package usecase
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
 )
 
-func NewForumInteractor(repo repository.Forum) *ForumInteractor {
+const (
+	// Tree levels returned below the roots when the client asks for no depth, and at most
+	defaultTreeDepth = 3
+	maxTreeDepth     = 10
+)
+
+func NewForumInteractor(repo repository.Forum, validator *Validator) *ForumInteractor {
 	return &ForumInteractor{
 		repository: repo,
+		validator:  validator,
 	}
 }
 
 type ForumInteractor struct {
 	repository repository.Forum
+	validator  *Validator
 }
 
 func (i *ForumInteractor) GetForum(slug string) (*forum.Forum, error) {
@@ -21,9 +32,110 @@ func (i *ForumInteractor) GetForum(slug string) (*forum.Forum, error) {
 }
 
 func (i *ForumInteractor) CreateForum(data *forum.Create) (*forum.Forum, error) {
-	return i.repository.CreateForum(data)
+	if err := i.validator.Forum(data); err != nil {
+		return nil, err
+	}
+
+	return i.repository.CreateForum(data, event.NewForumCreated)
 }
 
 func (i *ForumInteractor) GetForumUsers(slug string, limit *int, since *string, orderDesc bool) (*user.Users, error) {
 	return i.repository.GetForumUsers(slug, limit, since, orderDesc)
 }
+
+func (i *ForumInteractor) GetForumLeaders(slug string, limit *int, since *string, after *cursor.Cursor) (*user.Users, error) {
+	return i.repository.GetForumLeaders(slug, limit, since, after)
+}
+
+func (i *ForumInteractor) ListForums(creator *string, sort string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*forum.Forums, error) {
+	return i.repository.ListForums(creator, sort, limit, since, after, orderDesc)
+}
+
+func (i *ForumInteractor) CountForumUsers(slug string) (*page.Total, error) {
+	return i.repository.CountForumUsers(slug)
+}
+
+func (i *ForumInteractor) CountForums(creator *string) (*page.Total, error) {
+	return i.repository.CountForums(creator)
+}
+
+// GetForumTree returns the forum with its subforums nested below it down to depth levels
+func (i *ForumInteractor) GetForumTree(slug string, depth *int) (*forum.Node, error) {
+	forums, err := i.repository.GetForumTree(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	// The parent of the requested forum is not in the list, so it is the only root
+	tree := buildTree(*forums, treeDepth(depth))
+	return &tree[0], nil
+}
+
+// GetTree returns the hierarchy down to depth levels, forums without a parent at the top
+func (i *ForumInteractor) GetTree(depth *int) (*forum.Tree, error) {
+	forums, err := i.repository.GetForumHierarchy()
+	if err != nil {
+		return nil, err
+	}
+
+	tree := buildTree(*forums, treeDepth(depth))
+	return &tree, nil
+}
+
+func treeDepth(depth *int) int {
+	if depth == nil {
+		return defaultTreeDepth
+	}
+	if *depth > maxTreeDepth {
+		return maxTreeDepth
+	}
+	return *depth
+}
+
+// buildTree nests the forums below their parents and rolls the counters of every subtree up into its root.
+// Forums whose parent is not in the list become roots, the list order is kept within each level.
+// Levels below depth are left out of the nesting but still counted, which is why all forums are read.
+func buildTree(forums forum.Forums, depth int) forum.Tree {
+	present := make(map[string]bool, len(forums))
+	for _, received := range forums {
+		present[received.Slug] = true
+	}
+
+	children := make(map[string][]int, len(forums))
+	roots := make([]int, 0)
+	for idx, received := range forums {
+		if received.Parent != nil && present[*received.Parent] {
+			children[*received.Parent] = append(children[*received.Parent], idx)
+		} else {
+			roots = append(roots, idx)
+		}
+	}
+
+	var build func(idx, level int) forum.Node
+	build = func(idx, level int) forum.Node {
+		node := forum.Node{
+			Forum:        forums[idx],
+			TotalThreads: forums[idx].Threads,
+			TotalPosts:   forums[idx].Posts,
+			Children:     make(forum.Tree, 0),
+		}
+		for _, child := range children[forums[idx].Slug] {
+			subtree := build(child, level+1)
+			node.TotalThreads += subtree.TotalThreads
+			node.TotalPosts += subtree.TotalPosts
+			if level < depth {
+				node.Children = append(node.Children, subtree)
+			} else {
+				node.More = true
+			}
+		}
+		return node
+	}
+
+	tree := make(forum.Tree, 0, len(roots))
+	for _, idx := range roots {
+		tree = append(tree, build(idx, 0))
+	}
+
+	return tree
+}
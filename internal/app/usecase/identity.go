@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// errUnauthorized is returned when a request doesn't prove the identity it acts under
+var errUnauthorized = errors.New("unauthorized")
+
+func NewIdentities(secret string) *Identities {
+	return &Identities{
+		secret: []byte(secret),
+	}
+}
+
+// Identities issues and checks the tokens users prove their nickname with.
+// A token is the nickname and its HMAC under the server secret, without a secret none is accepted.
+type Identities struct {
+	secret []byte
+}
+
+func (i *Identities) Issue(nickname string) string {
+	return nickname + "." + i.sign(nickname)
+}
+
+// Verify returns the nickname a token was issued for
+func (i *Identities) Verify(token string) (string, error) {
+	split := strings.LastIndex(token, ".")
+	if len(i.secret) == 0 || split <= 0 {
+		return "", errUnauthorized
+	}
+
+	nickname, signature := token[:split], token[split+1:]
+	if !hmac.Equal([]byte(signature), []byte(i.sign(nickname))) {
+		return "", errUnauthorized
+	}
+
+	return nickname, nil
+}
+
+// optional verifies the token of a request that may be anonymous, those get a nil nickname
+func (i *Identities) optional(token string) (*string, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	nickname, err := i.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nickname, nil
+}
+
+func (i *Identities) sign(nickname string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(strings.ToLower(nickname)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxQuoteDepth bounds nested blockquotes, deeper markers are rendered as text
+const maxQuoteDepth = 8
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?$`)
+	rulePattern     = regexp.MustCompile(`^(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	listPattern     = regexp.MustCompile(`^(?:([-*+])|(\d{1,9})[.)])\s+(.*)$`)
+	languagePattern = regexp.MustCompile(`^[A-Za-z0-9_+-]{1,32}$`)
+
+	codeSpanPattern = regexp.MustCompile("`([^`]+)`")
+	linkPattern     = regexp.MustCompile(`\[([^\[\]]+)\]\(([^()\s]+)\)`)
+	autoLinkPattern = regexp.MustCompile(`&lt;((?:https?://|mailto:)[^\s<>]+?)&gt;`)
+	strongPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	deletedPattern  = regexp.MustCompile(`~~([^~]+)~~`)
+	starEmPattern   = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	underEmPattern  = regexp.MustCompile(`(^|[^A-Za-z0-9_])_([^_\s][^_]*)_($|[^A-Za-z0-9_])`)
+	stashPattern    = regexp.MustCompile("\x00([0-9]+)\x00")
+)
+
+// renderMarkdown converts a post or thread message to HTML.
+// Input is escaped before any markup is produced, so the output only contains the tags emitted here:
+// p, br, h1-h6, hr, blockquote, ul, ol, li, pre, code, strong, em, del and a.
+// Links keep http, https, mailto and site relative targets only.
+func renderMarkdown(message string) string {
+	message = strings.Replace(message, "\r\n", "\n", -1)
+	message = strings.Replace(message, "\x00", "", -1)
+
+	var out strings.Builder
+	renderBlocks(&out, strings.Split(message, "\n"), 0)
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func renderBlocks(out *strings.Builder, lines []string, depth int) {
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimSpace(lines[i])
+
+		switch {
+		case trimmed == "":
+			i++
+		case strings.HasPrefix(trimmed, "```"):
+			i = renderFence(out, lines, i)
+		case rulePattern.MatchString(trimmed):
+			out.WriteString("<hr>\n")
+			i++
+		case headingPattern.MatchString(trimmed):
+			match := headingPattern.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(match[1]))
+			out.WriteString("<h" + level + ">" + renderInline(match[2]) + "</h" + level + ">\n")
+			i++
+		case strings.HasPrefix(trimmed, ">") && depth < maxQuoteDepth:
+			quoted := make([]string, 0)
+			for ; i < len(lines); i++ {
+				line := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(line, ">") {
+					break
+				}
+				quoted = append(quoted, strings.TrimPrefix(line[1:], " "))
+			}
+			out.WriteString("<blockquote>\n")
+			renderBlocks(out, quoted, depth+1)
+			out.WriteString("</blockquote>\n")
+		case listPattern.MatchString(trimmed):
+			i = renderList(out, lines, i)
+		default:
+			paragraph := make([]string, 0)
+			for ; i < len(lines); i++ {
+				line := strings.TrimSpace(lines[i])
+				if line == "" || (len(paragraph) != 0 && startsBlock(line)) {
+					break
+				}
+				paragraph = append(paragraph, renderInline(line))
+			}
+			out.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+		}
+	}
+}
+
+// startsBlock reports whether a line interrupts a paragraph
+func startsBlock(line string) bool {
+	return strings.HasPrefix(line, "```") || strings.HasPrefix(line, ">") ||
+		rulePattern.MatchString(line) || headingPattern.MatchString(line) || listPattern.MatchString(line)
+}
+
+// renderFence writes a fenced code block verbatim, an unterminated fence runs to the end of the message
+func renderFence(out *strings.Builder, lines []string, start int) int {
+	language := strings.TrimSpace(strings.TrimSpace(lines[start])[3:])
+
+	code := make([]string, 0)
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+			i++
+			break
+		}
+		code = append(code, html.EscapeString(lines[i]))
+	}
+
+	if languagePattern.MatchString(language) {
+		out.WriteString(`<pre><code class="language-` + language + `">`)
+	} else {
+		out.WriteString("<pre><code>")
+	}
+	out.WriteString(strings.Join(code, "\n") + "</code></pre>\n")
+
+	return i
+}
+
+// renderList writes consecutive items of one list kind, indented lines continue the previous item
+func renderList(out *strings.Builder, lines []string, start int) int {
+	first := listPattern.FindStringSubmatch(strings.TrimSpace(lines[start]))
+	ordered := first[1] == ""
+
+	if !ordered {
+		out.WriteString("<ul>\n")
+	} else if number, _ := strconv.Atoi(first[2]); number != 1 {
+		out.WriteString(`<ol start="` + strconv.Itoa(number) + `">` + "\n")
+	} else {
+		out.WriteString("<ol>\n")
+	}
+
+	items := make([][]string, 0)
+	i := start
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			break
+		}
+
+		if match := listPattern.FindStringSubmatch(line); match != nil {
+			if (match[1] == "") != ordered {
+				break
+			}
+			items = append(items, []string{renderInline(match[3])})
+			continue
+		}
+
+		if lines[i] == line || startsBlock(line) {
+			break
+		}
+		items[len(items)-1] = append(items[len(items)-1], renderInline(line))
+	}
+
+	for _, item := range items {
+		out.WriteString("<li>" + strings.Join(item, "<br>\n") + "</li>\n")
+	}
+
+	if ordered {
+		out.WriteString("</ol>\n")
+	} else {
+		out.WriteString("</ul>\n")
+	}
+
+	return i
+}
+
+// renderInline escapes a line and applies span markup. Code spans and links are stashed
+// behind NUL delimited indexes first, so emphasis never applies inside them.
+func renderInline(text string) string {
+	text = html.EscapeString(text)
+	stash := make([]string, 0)
+	hide := func(rendered string) string {
+		stash = append(stash, rendered)
+		return "\x00" + strconv.Itoa(len(stash)-1) + "\x00"
+	}
+
+	text = codeSpanPattern.ReplaceAllStringFunc(text, func(span string) string {
+		return hide("<code>" + span[1:len(span)-1] + "</code>")
+	})
+
+	text = linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		if !safeURL(match[2]) {
+			return link
+		}
+		return hide(`<a href="` + match[2] + `" rel="nofollow ugc">` + renderEmphasis(match[1]) + "</a>")
+	})
+
+	text = autoLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		target := autoLinkPattern.FindStringSubmatch(link)[1]
+		return hide(`<a href="` + target + `" rel="nofollow ugc">` + target + "</a>")
+	})
+
+	text = renderEmphasis(text)
+
+	// Link texts may hold stashed code spans, so restoring runs until nothing is left
+	for stashPattern.MatchString(text) {
+		text = stashPattern.ReplaceAllStringFunc(text, func(key string) string {
+			index, _ := strconv.Atoi(key[1 : len(key)-1])
+			return stash[index]
+		})
+	}
+
+	return text
+}
+
+func renderEmphasis(text string) string {
+	text = strongPattern.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = deletedPattern.ReplaceAllString(text, "<del>$1</del>")
+	text = starEmPattern.ReplaceAllString(text, "<em>$1</em>")
+	return underEmPattern.ReplaceAllString(text, "$1<em>$2</em>$3")
+}
+
+// safeURL accepts escaped link targets with an allowed scheme or no scheme at all
+func safeURL(target string) bool {
+	lower := strings.ToLower(target)
+	for _, prefix := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return len(target) > len(prefix)
+		}
+	}
+
+	// Browsers read both //host and /\host as another site
+	if strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return false
+	}
+	return strings.HasPrefix(target, "/") || strings.HasPrefix(target, "#")
+}
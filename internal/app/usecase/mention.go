@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxMentions bounds the nicknames resolved for a single message
+const maxMentions = 50
+
+// mentionPattern matches @nickname not preceded by a nickname character, so e-mail addresses are skipped
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.@])@([A-Za-z0-9_.]+)`)
+
+// parseMentions returns the distinct nicknames mentioned in a message, compared case-insensitively
+func parseMentions(message string) []string {
+	if !strings.Contains(message, "@") {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	mentions := make([]string, 0)
+	for _, match := range mentionPattern.FindAllStringSubmatch(message, -1) {
+		// A trailing dot ends the sentence rather than the nickname
+		nickname := strings.TrimRight(match[1], ".")
+		key := strings.ToLower(nickname)
+		if nickname == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		mentions = append(mentions, nickname)
+		if len(mentions) == maxMentions {
+			break
+		}
+	}
+
+	return mentions
+}
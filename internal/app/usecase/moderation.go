@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/moderation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+// errForbidden is returned when the acting user lacks the forum role an operation needs
+var errForbidden = errors.New("forbidden")
+
+// errAuthorBanned keeps a quarantined post of a banned author from being published
+var errAuthorBanned = errors.New("authorBanned")
+
+func NewModerationInteractor(repo repository.Moderation, posts repository.Post, validator *Validator, bans *BanInteractor, identities *Identities) *ModerationInteractor {
+	return &ModerationInteractor{
+		repository: repo,
+		posts:      posts,
+		validator:  validator,
+		bans:       bans,
+		identities: identities,
+	}
+}
+
+// ModerationInteractor runs the per forum moderation queue.
+// Forum owners and their moderators work the queue, only owners appoint moderators.
+// The acting user is always the one the request token was issued for.
+type ModerationInteractor struct {
+	repository repository.Moderation
+	posts      repository.Post
+	validator  *Validator
+	bans       *BanInteractor
+	identities *Identities
+}
+
+func (i *ModerationInteractor) GetModerators(slug string) (*moderation.Moderators, error) {
+	return i.repository.GetModerators(slug)
+}
+
+func (i *ModerationInteractor) AddModerator(slug, nickname, token string) (*moderation.Moderator, error) {
+	owner, err := i.authorize(slug, token, moderation.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.repository.AddModerator(slug, nickname, owner)
+}
+
+func (i *ModerationInteractor) RemoveModerator(slug, nickname, token string) error {
+	if _, err := i.authorize(slug, token, moderation.RoleOwner); err != nil {
+		return err
+	}
+
+	return i.repository.RemoveModerator(slug, nickname)
+}
+
+func (i *ModerationInteractor) ReportPost(id uint64, data *moderation.Report) (*moderation.Item, error) {
+	if err := i.validator.Report(data); err != nil {
+		return nil, err
+	}
+
+	return i.repository.ReportPost(id, data)
+}
+
+func (i *ModerationInteractor) GetQueue(slug, token string, limit *int, since *string, orderDesc bool) (*moderation.Items, error) {
+	if _, err := i.authorize(slug, token, moderation.RoleModerator); err != nil {
+		return nil, err
+	}
+
+	return i.repository.GetQueue(slug, limit, since, orderDesc)
+}
+
+func (i *ModerationInteractor) CountQueue(slug string) (*page.Total, error) {
+	return i.repository.CountQueue(slug)
+}
+
+func (i *ModerationInteractor) GetActions(slug, token string, limit *int, since *string, orderDesc bool) (*moderation.Actions, error) {
+	if _, err := i.authorize(slug, token, moderation.RoleModerator); err != nil {
+		return nil, err
+	}
+
+	return i.repository.GetActions(slug, limit, since, orderDesc)
+}
+
+func (i *ModerationInteractor) CountActions(slug string) (*page.Total, error) {
+	return i.repository.CountActions(slug)
+}
+
+// Resolve applies a moderator decision to the post behind a queue item and records it.
+// Reported posts are hidden by hide and ban or removed by delete. Quarantined posts are
+// published by dismiss and dropped by any other action. The item is claimed in the same
+// transaction as the action, so concurrent decisions on one item apply only once.
+// A ban placed by the decision is authorized like the ones placed through the ban routes.
+func (i *ModerationInteractor) Resolve(slug string, id uint64, data *moderation.Decision, token string) (*moderation.Action, error) {
+	if err := i.validator.Decision(data); err != nil {
+		return nil, err
+	}
+
+	moderator, err := i.authorize(slug, token, moderation.RoleModerator)
+	if err != nil {
+		return nil, err
+	}
+	data.Moderator = moderator
+
+	item, err := i.repository.GetItem(slug, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var held *post.Create
+	if item.Kind == moderation.KindQuarantine && data.Action == moderation.ActionDismiss {
+		if held, err = i.release(item.Target); err != nil {
+			return nil, err
+		}
+	}
+
+	return i.repository.ResolveItem(item, data, held, event.NewPostCreated)
+}
+
+// release prepares a quarantined post for publishing, the content checks are not repeated
+// but a ban of the author placed while the post was held still keeps it out
+func (i *ModerationInteractor) release(id uint64) (*post.Create, error) {
+	held, threadID, err := i.posts.GetQuarantined(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.bans.CheckThread([]string{held.UserNickname}, strconv.FormatUint(threadID, 10)); err != nil {
+		if _, ok := err.(*ban.Error); ok {
+			return nil, errAuthorBanned
+		}
+		return nil, err
+	}
+
+	held.Mentions = parseMentions(held.Message)
+	held.Rendered = renderMarkdown(held.Message)
+
+	return held, nil
+}
+
+// authorize returns the holder of the token when they have the owner role, or any forum role
+// when role is RoleModerator
+func (i *ModerationInteractor) authorize(slug, token, role string) (string, error) {
+	nickname, err := i.identities.Verify(token)
+	if err != nil {
+		return "", err
+	}
+
+	granted, err := i.repository.GetRole(slug, nickname)
+	if err != nil {
+		return "", err
+	}
+
+	if granted == "" || (role == moderation.RoleOwner && granted != moderation.RoleOwner) {
+		return "", errForbidden
+	}
+
+	return nickname, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/notification"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+func NewNotificationInteractor(repo repository.Notification) *NotificationInteractor {
+	return &NotificationInteractor{
+		repository: repo,
+	}
+}
+
+type NotificationInteractor struct {
+	repository repository.Notification
+}
+
+func (i *NotificationInteractor) SubscribeThread(slugOrId string, nickname string) (*notification.Subscription, error) {
+	return i.repository.SubscribeThread(slugOrId, nickname)
+}
+
+func (i *NotificationInteractor) UnsubscribeThread(slugOrId string, nickname string) (*notification.Subscription, error) {
+	return i.repository.UnsubscribeThread(slugOrId, nickname)
+}
+
+func (i *NotificationInteractor) SubscribeForum(slug string, nickname string) (*notification.Subscription, error) {
+	return i.repository.SubscribeForum(slug, nickname)
+}
+
+func (i *NotificationInteractor) UnsubscribeForum(slug string, nickname string) (*notification.Subscription, error) {
+	return i.repository.UnsubscribeForum(slug, nickname)
+}
+
+func (i *NotificationInteractor) GetNotifications(nickname string, limit *int, since *string, orderDesc bool, unread bool) (*notification.Notifications, error) {
+	return i.repository.GetNotifications(nickname, limit, since, orderDesc, unread)
+}
+
+func (i *NotificationInteractor) CountNotifications(nickname string, unread bool) (*page.Total, error) {
+	return i.repository.CountNotifications(nickname, unread)
+}
+
+func (i *NotificationInteractor) MarkRead(nickname string, ids []uint64) (*notification.Unread, error) {
+	return i.repository.MarkRead(nickname, ids)
+}
+
+// Handle fills the inboxes of users following the thread or forum of new posts and threads,
+// and of users answered or mentioned by a post
+func (i *NotificationInteractor) Handle(e *event.Event) error {
+	typed, err := e.Decode()
+	if err == event.ErrUnknownType {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch typed := typed.(type) {
+	case event.PostCreated:
+		return i.repository.NotifyPost(e.ID, typed.Post)
+	case event.PostUpdated:
+		if len(typed.Post.Mentions) == 0 {
+			return nil
+		}
+		return i.repository.NotifyMentions(e.ID, typed.Post)
+	case event.ThreadCreated:
+		return i.repository.NotifyThread(e.ID, typed.Thread)
+	}
+
+	return nil
+}
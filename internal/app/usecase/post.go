@@ -1,38 +1,107 @@
 package usecase
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
 )
 
-func NewPostInteractor(repo repository.Post) *PostInteractor {
+func NewPostInteractor(repo repository.Post, validator *Validator, protection *Protection, bans *BanInteractor, identities *Identities) *PostInteractor {
 	return &PostInteractor{
 		repository: repo,
+		validator:  validator,
+		protection: protection,
+		bans:       bans,
+		identities: identities,
 	}
 }
 
 type PostInteractor struct {
 	repository repository.Post
+	validator  *Validator
+	protection *Protection
+	bans       *BanInteractor
+	identities *Identities
 }
 
 func (i *PostInteractor) GetPost(id string, related map[string]bool) (*post.Info, error) {
 	return i.repository.GetPost(id, related)
 }
 
-func (i *PostInteractor) UpdatePost(data *post.Update) (*post.Post, error) {
-	return i.repository.UpdatePost(data)
+// UpdatePost attributes the edit to the holder of the token, or to the author without one
+func (i *PostInteractor) UpdatePost(data *post.Update, token string) (*post.Post, error) {
+	if err := i.validator.PostUpdate(data); err != nil {
+		return nil, err
+	}
+
+	editor, err := i.identities.optional(token)
+	if err != nil {
+		return nil, err
+	}
+	data.Editor = editor
+
+	if err := i.bans.CheckPostEdit(data.Editor, data.ID); err != nil {
+		return nil, err
+	}
+
+	if data.Message != nil {
+		rendered := renderMarkdown(*data.Message)
+		data.Mentions = parseMentions(*data.Message)
+		data.Rendered = &rendered
+	}
+
+	return i.repository.UpdatePost(data, event.NewPostUpdated)
 }
 
+// CreatePosts publishes the batch except for quarantined posts, which are held for moderators
 func (i *PostInteractor) CreatePosts(data *post.PostsCreate, slugOrId string) (*post.Posts, error) {
-	return i.repository.CreatePosts(data, slugOrId)
+	if err := i.validator.Posts(data); err != nil {
+		return nil, err
+	}
+
+	authors := make([]string, len(*data))
+	for idx := range *data {
+		authors[idx] = (*data)[idx].UserNickname
+	}
+
+	if err := i.bans.CheckThread(authors, slugOrId); err != nil {
+		return nil, err
+	}
+
+	if err := i.protection.CheckPosts(data); err != nil {
+		return nil, err
+	}
+
+	published := make(post.PostsCreate, 0, len(*data))
+	held := make(post.PostsCreate, 0)
+	for idx := range *data {
+		(*data)[idx].Mentions = parseMentions((*data)[idx].Message)
+		(*data)[idx].Rendered = renderMarkdown((*data)[idx].Message)
+
+		if (*data)[idx].Quarantine != "" {
+			held = append(held, (*data)[idx])
+		} else {
+			published = append(published, (*data)[idx])
+		}
+	}
+
+	posts, err := i.repository.CreatePosts(&published, &held, slugOrId, event.NewPostCreated)
+	if err != nil {
+		i.protection.Forget(data)
+		return nil, err
+	}
+
+	return posts, nil
 }
 
 func (i *PostInteractor) GetPosts(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
 	return i.repository.GetPosts(slugOrId, limit, since, orderDesc)
 }
 
-func (i *PostInteractor) GetPostsParentTree(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
-	return i.repository.GetPostsParentTree(slugOrId, limit, since, orderDesc)
+func (i *PostInteractor) GetPostsParentTree(slugOrId string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*post.Posts, error) {
+	return i.repository.GetPostsParentTree(slugOrId, limit, since, after, orderDesc)
 }
 
 func (i *PostInteractor) GetPostsTree(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
@@ -42,3 +111,77 @@ func (i *PostInteractor) GetPostsTree(slugOrId string, limit *int, since *string
 func (i *PostInteractor) GetPostsFlat(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
 	return i.repository.GetPostsFlat(slugOrId, limit, since, orderDesc)
 }
+
+func (i *PostInteractor) GetPostsTop(slugOrId string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*post.Posts, error) {
+	return i.repository.GetPostsTop(slugOrId, limit, since, after, orderDesc)
+}
+
+func (i *PostInteractor) GetPostHistory(id string) (*post.Revisions, error) {
+	return i.repository.GetPostHistory(id)
+}
+
+// GetPostDiff compares two revisions of the post, the current message is used when to is omitted
+func (i *PostInteractor) GetPostDiff(id string, from uint64, to *uint64) (*post.Diff, error) {
+	fromRevision, err := i.repository.GetPostRevision(id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var target string
+	if to != nil {
+		toRevision, err := i.repository.GetPostRevision(id, *to)
+		if err != nil {
+			return nil, err
+		}
+		target = toRevision.Message
+	} else {
+		current, err := i.repository.GetPost(id, nil)
+		if err != nil {
+			return nil, err
+		}
+		target = current.Post.Message
+	}
+
+	return &post.Diff{
+		From:  from,
+		To:    to,
+		Lines: diffLines(fromRevision.Message, target),
+	}, nil
+}
+
+func (i *PostInteractor) GetUserPosts(nickname string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
+	return i.repository.GetUserPosts(nickname, limit, since, orderDesc)
+}
+
+func (i *PostInteractor) CountPosts(slugOrId string) (*page.Total, error) {
+	return i.repository.CountPosts(slugOrId)
+}
+
+func (i *PostInteractor) CountUserPosts(nickname string) (*page.Total, error) {
+	return i.repository.CountUserPosts(nickname)
+}
+
+func (i *PostInteractor) GetUserMentions(nickname string, limit *int, since *string, orderDesc bool) (*post.Posts, error) {
+	return i.repository.GetUserMentions(nickname, limit, since, orderDesc)
+}
+
+func (i *PostInteractor) CountUserMentions(nickname string) (*page.Total, error) {
+	return i.repository.CountUserMentions(nickname)
+}
+
+// RenderPosts fills MessageHTML from the rendering read along with the posts, older posts stored without one are rendered on the fly
+func (i *PostInteractor) RenderPosts(posts post.Posts) {
+	for idx := range posts {
+		if posts[idx].Rendered != "" {
+			posts[idx].MessageHTML = posts[idx].Rendered
+		} else {
+			posts[idx].MessageHTML = renderMarkdown(posts[idx].Message)
+		}
+	}
+}
+
+func (i *PostInteractor) RenderPost(received *post.Post) {
+	posts := post.Posts{*received}
+	i.RenderPosts(posts)
+	received.MessageHTML = posts[0].MessageHTML
+}
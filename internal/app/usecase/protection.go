@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/abuse"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+func NewProtection(limiter repository.RateLimiter, window time.Duration, filters ...repository.ContentFilter) *Protection {
+	return &Protection{
+		limiter: limiter,
+		filters: filters,
+		window:  window,
+		recent:  make(map[string]time.Time),
+		swept:   time.Now(),
+	}
+}
+
+// Protection guards post creation against floods and spam.
+// A nil limiter or a zero window disables the matching check.
+type Protection struct {
+	limiter repository.RateLimiter
+	filters []repository.ContentFilter
+	window  time.Duration
+	mu      sync.Mutex
+	recent  map[string]time.Time
+	swept   time.Time
+}
+
+// CheckPosts applies the content filters, duplicate detection and the per author rate limit to a batch.
+// Any rejection fails the whole batch, quarantined posts get their Quarantine reason set.
+// Tokens are only taken from batches passing the other checks, and an accepted batch is
+// recorded for duplicate detection right away, Forget releases it when creation fails.
+func (p *Protection) CheckPosts(data *post.PostsCreate) error {
+	for idx := range *data {
+		created := &(*data)[idx]
+		for _, filter := range p.filters {
+			verdict := filter.Check(created.Message)
+			switch verdict.Action {
+			case abuse.ActionReject:
+				return &abuse.Error{
+					Description: "Post rejected by content filter",
+					Reason:      abuse.ReasonFiltered,
+					Detail:      "posts[" + strconv.Itoa(idx) + "]: " + verdict.Reason,
+				}
+			case abuse.ActionQuarantine:
+				if created.Quarantine == "" {
+					created.Quarantine = verdict.Reason
+				}
+			}
+		}
+	}
+
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys, err := p.checkDuplicates(data, now)
+	if err != nil {
+		return err
+	}
+
+	if err := p.allow(data); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		p.recent[key] = now
+	}
+
+	return nil
+}
+
+// Forget drops the messages of a batch that was checked but could not be created
+func (p *Protection) Forget(data *post.PostsCreate) {
+	if p.window == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for idx := range *data {
+		delete(p.recent, duplicateKey(&(*data)[idx]))
+	}
+}
+
+// allow takes a token per post from the bucket of every author in the batch
+func (p *Protection) allow(data *post.PostsCreate) error {
+	if p.limiter == nil {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, created := range *data {
+		counts[strings.ToLower(created.UserNickname)]++
+	}
+
+	for author, count := range counts {
+		if ok, wait := p.limiter.Allow("posts:"+author, count); !ok {
+			return &abuse.Error{
+				Description: "Too many posts, try again later",
+				Reason:      abuse.ReasonRateLimited,
+				RetryAfter:  wait,
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDuplicates must be called with mu held. It rejects a message its author already posted
+// within the window, batches included, and returns the keys to record for an accepted batch.
+func (p *Protection) checkDuplicates(data *post.PostsCreate, now time.Time) ([]string, error) {
+	if p.window == 0 {
+		return nil, nil
+	}
+
+	if now.Sub(p.swept) >= p.window {
+		for key, created := range p.recent {
+			if now.Sub(created) >= p.window {
+				delete(p.recent, key)
+			}
+		}
+		p.swept = now
+	}
+
+	keys := make([]string, 0, len(*data))
+	batch := make(map[string]bool, len(*data))
+	for idx := range *data {
+		key := duplicateKey(&(*data)[idx])
+		if created, ok := p.recent[key]; batch[key] || (ok && now.Sub(created) < p.window) {
+			return nil, &abuse.Error{
+				Description: "Duplicate post, the same message was just posted",
+				Reason:      abuse.ReasonDuplicate,
+				Detail:      "posts[" + strconv.Itoa(idx) + "]",
+			}
+		}
+		batch[key] = true
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// duplicateKey identifies a message by its author and a hash, so long messages are not kept around
+func duplicateKey(created *post.Create) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(created.Message))
+	return strings.ToLower(created.UserNickname) + ":" + strconv.FormatUint(hash.Sum64(), 16)
+}
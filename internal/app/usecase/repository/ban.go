@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+
+type Ban interface {
+	GetBans(nickname string) (*ban.Bans, error)
+	GetBan(nickname string, id uint64) (*ban.Ban, error)
+	CreateBan(nickname string, data *ban.Create) (*ban.Ban, error)
+	DeleteBan(id uint64) error
+	FindActiveBan(nicknames []string, forumSlug string) (*ban.Ban, error)
+	FindActiveThreadBan(nicknames []string, slugOrId string, author bool) (*ban.Ban, error)
+	FindActivePostBan(nicknames []string, id string, author bool) (*ban.Ban, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/bookmark"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+)
+
+type Bookmark interface {
+	CreateBookmark(nickname string, data *bookmark.Create) (*bookmark.Bookmark, error)
+	GetBookmarks(nickname string, folder *string, limit *int, since *string, orderDesc bool) (*bookmark.Bookmarks, error)
+	CountBookmarks(nickname string, folder *string) (*page.Total, error)
+	GetFolders(nickname string) (*bookmark.Folders, error)
+	DeleteBookmark(nickname string, id uint64) error
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+
+type Broker interface {
+	Subscribe(topic string) (<-chan *event.Event, func())
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/conversation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+)
+
+type Conversation interface {
+	StartConversation(nickname string, participants []string, subject, text string) (*conversation.Message, error)
+	SendMessage(nickname string, id uint64, text string) (*conversation.Message, error)
+	GetConversations(nickname string, limit *int, since *string, orderDesc bool, unread bool) (*conversation.Conversations, error)
+	CountConversations(nickname string, unread bool) (*page.Total, error)
+	GetMessages(nickname string, id uint64, limit *int, since *string, orderDesc bool) (*conversation.Messages, error)
+	CountMessages(id uint64) (*page.Total, error)
+	MarkRead(nickname string, id uint64) (*conversation.Unread, error)
+}
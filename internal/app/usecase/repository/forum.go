@@ -1,12 +1,21 @@
 package repository
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 )
 
 type Forum interface {
 	GetForum(slug string) (*forum.Forum, error)
-	CreateForum(data *forum.Create) (*forum.Forum, error)
+	CreateForum(data *forum.Create, emit func(*forum.Forum) event.Typed) (*forum.Forum, error)
 	GetForumUsers(slug string, limit *int, since *string, orderDesc bool) (*user.Users, error)
+	GetForumLeaders(slug string, limit *int, since *string, after *cursor.Cursor) (*user.Users, error)
+	ListForums(creator *string, sort string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*forum.Forums, error)
+	CountForumUsers(slug string) (*page.Total, error)
+	CountForums(creator *string) (*page.Total, error)
+	GetForumTree(slug string) (*forum.Forums, error)
+	GetForumHierarchy() (*forum.Forums, error)
 }
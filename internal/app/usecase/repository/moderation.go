@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/moderation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+)
+
+type Moderation interface {
+	GetRole(slug, nickname string) (string, error)
+	GetModerators(slug string) (*moderation.Moderators, error)
+	AddModerator(slug, nickname, addedBy string) (*moderation.Moderator, error)
+	RemoveModerator(slug, nickname string) error
+	ReportPost(id uint64, data *moderation.Report) (*moderation.Item, error)
+	GetItem(slug string, id uint64) (*moderation.Item, error)
+	GetQueue(slug string, limit *int, since *string, orderDesc bool) (*moderation.Items, error)
+	CountQueue(slug string) (*page.Total, error)
+	ResolveItem(item *moderation.Item, data *moderation.Decision, held *post.Create, emit func(*post.Post) event.Typed) (*moderation.Action, error)
+	GetActions(slug string, limit *int, since *string, orderDesc bool) (*moderation.Actions, error)
+	CountActions(slug string) (*page.Total, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/notification"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
+)
+
+type Notification interface {
+	SubscribeThread(slugOrId string, nickname string) (*notification.Subscription, error)
+	UnsubscribeThread(slugOrId string, nickname string) (*notification.Subscription, error)
+	SubscribeForum(slug string, nickname string) (*notification.Subscription, error)
+	UnsubscribeForum(slug string, nickname string) (*notification.Subscription, error)
+	NotifyPost(eventID uint64, created *post.Post) error
+	NotifyMentions(eventID uint64, updated *post.Post) error
+	NotifyThread(eventID uint64, created *thread.Thread) error
+	GetNotifications(nickname string, limit *int, since *string, orderDesc bool, unread bool) (*notification.Notifications, error)
+	CountNotifications(nickname string, unread bool) (*page.Total, error)
+	MarkRead(nickname string, ids []uint64) (*notification.Unread, error)
+}
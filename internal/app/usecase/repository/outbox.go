@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+)
+
+type Outbox interface {
+	ClaimEvents(limit int, lease time.Duration) (*event.Events, error)
+	DeleteEvents(ids []uint64) error
+	ReleaseEvent(id uint64, handled []string, retry time.Duration) (int, error)
+	DeadLetter(id uint64, subscriber string, reason string) error
+}
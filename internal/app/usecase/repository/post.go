@@ -1,15 +1,27 @@
 package repository
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
 )
 
 type Post interface {
 	GetPost(id string, related map[string]bool) (*post.Info, error)
-	UpdatePost(data *post.Update) (*post.Post, error)
-	CreatePosts(data *post.PostsCreate, slugOrId string) (*post.Posts, error)
+	UpdatePost(data *post.Update, emit func(*post.Post) event.Typed) (*post.Post, error)
+	CreatePosts(data *post.PostsCreate, held *post.PostsCreate, slugOrId string, emit func(*post.Post) event.Typed) (*post.Posts, error)
+	GetQuarantined(id uint64) (*post.Create, uint64, error)
 	GetPosts(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error)
-	GetPostsParentTree(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error)
+	GetPostsParentTree(slugOrId string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*post.Posts, error)
 	GetPostsTree(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error)
 	GetPostsFlat(slugOrId string, limit *int, since *string, orderDesc bool) (*post.Posts, error)
+	GetPostsTop(slugOrId string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*post.Posts, error)
+	GetPostHistory(id string) (*post.Revisions, error)
+	GetPostRevision(id string, revisionID uint64) (*post.Revision, error)
+	GetUserPosts(nickname string, limit *int, since *string, orderDesc bool) (*post.Posts, error)
+	CountPosts(slugOrId string) (*page.Total, error)
+	CountUserPosts(nickname string) (*page.Total, error)
+	GetUserMentions(nickname string, limit *int, since *string, orderDesc bool) (*post.Posts, error)
+	CountUserMentions(nickname string) (*page.Total, error)
 }
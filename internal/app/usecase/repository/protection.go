@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/abuse"
+)
+
+// RateLimiter takes cost tokens from the bucket of key or tells how long to wait for them
+type RateLimiter interface {
+	Allow(key string, cost int) (bool, time.Duration)
+}
+
+// ContentFilter inspects a message before it is published
+type ContentFilter interface {
+	Check(message string) abuse.Verdict
+}
@@ -0,0 +1,9 @@
+package repository
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/search"
+)
+
+type Search interface {
+	Search(query *search.Query) (*search.Results, error)
+}
@@ -1,12 +1,22 @@
 package repository
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 )
 
 type Thread interface {
 	GetThread(slugOrId string) (*thread.Thread, error)
-	GetThreads(slug string, limit *int, since *string, orderDesc bool) (*thread.Threads, error)
-	CreateThread(data *thread.Create) (*thread.Thread, error)
-	UpdateThread(data *thread.Update, slugOrId string) (*thread.Thread, error)
+	GetThreads(slug string, tag *string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error)
+	CreateThread(data *thread.Create, emit func(*thread.Thread) event.Typed) (*thread.Thread, error)
+	UpdateThread(data *thread.Update, slugOrId string, emit func(*thread.Thread) event.Typed) (*thread.Thread, error)
+	GetThreadHistory(slugOrId string) (*thread.Revisions, error)
+	GetUserThreads(nickname string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error)
+	CountThreads(slug string, tag *string) (*page.Total, error)
+	CountUserThreads(nickname string) (*page.Total, error)
+	GetTagThreads(tag string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error)
+	CountTagThreads(tag string) (*page.Total, error)
+	GetForumTags(slug string, limit *int) (*thread.Tags, error)
 }
@@ -1,11 +1,16 @@
 package repository
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 )
 
 type User interface {
 	GetUserByNickname(nickname string) (*user.User, error)
 	UpdateUser(data *user.Update, nickname string) (*user.User, error)
-	CreateUser(data *user.User) (*user.Users, error)
+	CreateUser(data *user.User, emit func(*user.User) event.Typed) (*user.Users, error)
+	GetUsers(query *string, sort string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*user.Users, error)
+	CountUsers(query *string) (*page.Total, error)
 }
@@ -1,10 +1,22 @@
 package repository
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/vote"
 )
 
 type Vote interface {
-	CreateVote(data *vote.Vote, slugOrId string) (*thread.Thread, error)
+	CreateVote(data *vote.Vote, slugOrId string, emit func(*thread.Thread) event.Typed) (*thread.Thread, error)
+	DeleteVote(data *vote.Vote, slugOrId string, emit func(*thread.Thread) event.Typed) (*thread.Thread, error)
+	GetThreadVotes(slugOrId string, limit *int, since *string, orderDesc bool) (*vote.Votes, error)
+	GetUserVotes(nickname string, limit *int, since *string, orderDesc bool) (*vote.Votes, error)
+	CreatePostVote(data *vote.Vote, id string, emit func(*post.Post) event.Typed) (*post.Post, error)
+	DeletePostVote(data *vote.Vote, id string, emit func(*post.Post) event.Typed) (*post.Post, error)
+	CreateReaction(data *vote.Reaction, id string, emit func(*post.Post) event.Typed) (*post.Post, error)
+	DeleteReaction(data *vote.Reaction, id string, emit func(*post.Post) event.Typed) (*post.Post, error)
+	CountThreadVotes(slugOrId string) (*page.Total, error)
+	CountUserVotes(nickname string) (*page.Total, error)
 }
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/webhook"
+)
+
+type Webhook interface {
+	CreateWebhook(slug string, data *webhook.Create) (*webhook.Webhook, error)
+	GetWebhooks(slug string) (*webhook.Webhooks, error)
+	GetWebhook(slug string, id uint64) (*webhook.Webhook, error)
+	UpdateWebhook(slug string, id uint64, data *webhook.Update) (*webhook.Webhook, error)
+	DeleteWebhook(slug string, id uint64) error
+	GetDeliveries(slug string, id uint64, limit *int, since *string, orderDesc bool) (*webhook.Deliveries, error)
+	CountDeliveries(id uint64) (*page.Total, error)
+	EnqueueDeliveries(e *event.Event) error
+	GetDueJobs(limit int) ([]webhook.Job, error)
+	CompleteDelivery(job *webhook.Job, status int) error
+	FailDelivery(job *webhook.Job, status *int, reason string, retryAt *time.Time, disableAfter int) error
+}
+
+// WebhookSender posts a delivery body to an endpoint and returns the response status
+type WebhookSender interface {
+	Send(url string, headers map[string]string, body []byte) (int, error)
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"strconv"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/search"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+func NewSearchInteractor(repo repository.Search) *SearchInteractor {
+	return &SearchInteractor{
+		repository: repo,
+	}
+}
+
+type SearchInteractor struct {
+	repository repository.Search
+}
+
+func (i *SearchInteractor) Search(query *search.Query) (*search.Results, error) {
+	results, err := i.repository.Search(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page means more results may follow the last one
+	if count := len(results.Results); count != 0 && count == query.Limit {
+		last := results.Results[count-1]
+		next := &cursor.Cursor{
+			Key:  strconv.FormatFloat(float64(last.Rank), 'g', -1, 32),
+			Type: last.Type,
+			ID:   last.ID,
+		}
+		token := next.Encode()
+		results.Cursor = &token
+	}
+
+	return results, nil
+}
@@ -21,3 +21,7 @@ func (i *ServiceInteractor) GetStatus() (*service.Status, error) {
 func (i *ServiceInteractor) Clear() error {
 	return i.repository.Clear()
 }
+
+func (i *ServiceInteractor) RecomputeReputation() error {
+	return i.repository.RecomputeReputation()
+}
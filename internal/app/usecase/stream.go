@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+func NewStreamInteractor(broker repository.Broker, threadRepo repository.Thread, forumRepo repository.Forum) *StreamInteractor {
+	return &StreamInteractor{
+		broker:  broker,
+		threads: threadRepo,
+		forums:  forumRepo,
+	}
+}
+
+type StreamInteractor struct {
+	broker  repository.Broker
+	threads repository.Thread
+	forums  repository.Forum
+}
+
+func (i *StreamInteractor) SubscribeThread(slugOrId string) (<-chan *event.Event, func(), error) {
+	received, err := i.threads.GetThread(slugOrId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events, cancel := i.broker.Subscribe(event.ThreadTopic(received.ID))
+	return events, cancel, nil
+}
+
+func (i *StreamInteractor) SubscribeForum(slug string) (<-chan *event.Event, func(), error) {
+	received, err := i.forums.GetForum(slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events, cancel := i.broker.Subscribe(event.ForumTopic(received.Slug))
+	return events, cancel, nil
+}
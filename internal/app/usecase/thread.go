@@ -1,32 +1,156 @@
 package usecase
 
 import (
+	"sort"
+	"strings"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
 )
 
-func NewThreadInteractor(repo repository.Thread) *ThreadInteractor {
+func NewThreadInteractor(repo repository.Thread, validator *Validator, bans *BanInteractor, identities *Identities) *ThreadInteractor {
 	return &ThreadInteractor{
 		repository: repo,
+		validator:  validator,
+		bans:       bans,
+		identities: identities,
 	}
 }
 
 type ThreadInteractor struct {
 	repository repository.Thread
+	validator  *Validator
+	bans       *BanInteractor
+	identities *Identities
 }
 
 func (i *ThreadInteractor) GetThread(slugOrId string) (*thread.Thread, error) {
 	return i.repository.GetThread(slugOrId)
 }
 
-func (i *ThreadInteractor) GetThreads(slug string, limit *int, since *string, orderDesc bool) (*thread.Threads, error) {
-	return i.repository.GetThreads(slug, limit, since, orderDesc)
+func (i *ThreadInteractor) GetThreads(slug string, tag *string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error) {
+	if tag != nil {
+		normalized := normalizeTag(*tag)
+		tag = &normalized
+	}
+
+	return i.repository.GetThreads(slug, tag, limit, since, after, orderDesc)
 }
 
 func (i *ThreadInteractor) CreateThread(data *thread.Create) (*thread.Thread, error) {
-	return i.repository.CreateThread(data)
+	data.Tags = normalizeTags(data.Tags)
+
+	if err := i.validator.Thread(data); err != nil {
+		return nil, err
+	}
+
+	if err := i.bans.CheckForum([]string{data.UserNickname}, data.ForumSlug); err != nil {
+		return nil, err
+	}
+
+	data.Rendered = renderMarkdown(data.Message)
+
+	return i.repository.CreateThread(data, event.NewThreadCreated)
+}
+
+// UpdateThread attributes the edit to the holder of the token, or to the author without one
+func (i *ThreadInteractor) UpdateThread(data *thread.Update, slugOrId, token string) (*thread.Thread, error) {
+	if data.Tags != nil {
+		tags := normalizeTags(*data.Tags)
+		data.Tags = &tags
+	}
+
+	if err := i.validator.ThreadUpdate(data); err != nil {
+		return nil, err
+	}
+
+	editor, err := i.identities.optional(token)
+	if err != nil {
+		return nil, err
+	}
+	data.Editor = editor
+
+	if err := i.bans.CheckThreadEdit(data.Editor, slugOrId); err != nil {
+		return nil, err
+	}
+
+	if data.Message != nil {
+		rendered := renderMarkdown(*data.Message)
+		data.Rendered = &rendered
+	}
+
+	return i.repository.UpdateThread(data, slugOrId, event.NewThreadUpdated)
+}
+
+func (i *ThreadInteractor) GetThreadHistory(slugOrId string) (*thread.Revisions, error) {
+	return i.repository.GetThreadHistory(slugOrId)
+}
+
+func (i *ThreadInteractor) GetUserThreads(nickname string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error) {
+	return i.repository.GetUserThreads(nickname, limit, since, after, orderDesc)
+}
+
+func (i *ThreadInteractor) CountThreads(slug string, tag *string) (*page.Total, error) {
+	if tag != nil {
+		normalized := normalizeTag(*tag)
+		tag = &normalized
+	}
+
+	return i.repository.CountThreads(slug, tag)
+}
+
+func (i *ThreadInteractor) GetTagThreads(tag string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*thread.Threads, error) {
+	return i.repository.GetTagThreads(normalizeTag(tag), limit, since, after, orderDesc)
+}
+
+func (i *ThreadInteractor) CountTagThreads(tag string) (*page.Total, error) {
+	return i.repository.CountTagThreads(normalizeTag(tag))
+}
+
+func (i *ThreadInteractor) GetForumTags(slug string, limit *int) (*thread.Tags, error) {
+	return i.repository.GetForumTags(slug, limit)
+}
+
+func (i *ThreadInteractor) CountUserThreads(nickname string) (*page.Total, error) {
+	return i.repository.CountUserThreads(nickname)
+}
+
+// RenderThreads fills MessageHTML from the rendering read along with the threads, older threads stored without one are rendered on the fly
+func (i *ThreadInteractor) RenderThreads(threads thread.Threads) {
+	for idx := range threads {
+		if threads[idx].Rendered != "" {
+			threads[idx].MessageHTML = threads[idx].Rendered
+		} else {
+			threads[idx].MessageHTML = renderMarkdown(threads[idx].Message)
+		}
+	}
+}
+
+func (i *ThreadInteractor) RenderThread(received *thread.Thread) {
+	threads := thread.Threads{*received}
+	i.RenderThreads(threads)
+	received.MessageHTML = threads[0].MessageHTML
+}
+
+// normalizeTags lowercases the tags and drops repeated ones, so "Go" and "go" name the same tag
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	sort.Strings(normalized)
+	return normalized
 }
 
-func (i *ThreadInteractor) UpdateThread(data *thread.Update, slugOrId string) (*thread.Thread, error) {
-	return i.repository.UpdateThread(data, slugOrId)
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
 }
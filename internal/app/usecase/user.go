@@ -1,28 +1,67 @@
 package usecase
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/cursor"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
 )
 
-func NewUserInteractor(repo repository.User) *UserInteractor {
+func NewUserInteractor(repo repository.User, validator *Validator, bans *BanInteractor) *UserInteractor {
 	return &UserInteractor{
 		repository: repo,
+		validator:  validator,
+		bans:       bans,
 	}
 }
 
 type UserInteractor struct {
 	repository repository.User
+	validator  *Validator
+	bans       *BanInteractor
 }
 
 func (i *UserInteractor) GetUserByNickname(nickname string) (*user.User, error) {
 	return i.repository.GetUserByNickname(nickname)
 }
 
+// GetProfile adds the bans the holder of the token moderates to the profile, token may be empty
+func (i *UserInteractor) GetProfile(nickname, token string) (*user.User, error) {
+	profile, err := i.repository.GetUserByNickname(nickname)
+	if err != nil || token == "" {
+		return profile, err
+	}
+
+	bans, err := i.bans.GetBans(nickname, token)
+	if err != nil {
+		return nil, err
+	}
+	profile.Bans = *bans
+
+	return profile, nil
+}
+
 func (i *UserInteractor) UpdateUser(data *user.Update, nickname string) (*user.User, error) {
+	if err := i.validator.UserUpdate(data); err != nil {
+		return nil, err
+	}
+
 	return i.repository.UpdateUser(data, nickname)
 }
 
 func (i *UserInteractor) CreateUser(data *user.User) (*user.Users, error) {
-	return i.repository.CreateUser(data)
+	if err := i.validator.User(data); err != nil {
+		return nil, err
+	}
+
+	return i.repository.CreateUser(data, event.NewUserCreated)
+}
+
+func (i *UserInteractor) GetUsers(query *string, sort string, limit *int, since *string, after *cursor.Cursor, orderDesc bool) (*user.Users, error) {
+	return i.repository.GetUsers(query, sort, limit, since, after, orderDesc)
+}
+
+func (i *UserInteractor) CountUsers(query *string) (*page.Total, error) {
+	return i.repository.CountUsers(query)
 }
@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/ban"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/bookmark"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/conversation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/forum"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/moderation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/user"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/validation"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/vote"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/webhook"
+)
+
+var (
+	emailPattern    = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	nicknamePattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+	// Slugs need a character besides digits, otherwise they could not be told apart from thread ids
+	slugPattern = regexp.MustCompile(`^[A-Za-z0-9_-]*[A-Za-z_-][A-Za-z0-9_-]*$`)
+	// Tags are single words, punctuation such as in c++ or c# is allowed after the first character
+	tagPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_.+#-]*$`)
+	// Reactions are single words or emoji, joiners and variation selectors included
+	reactionPattern = regexp.MustCompile(`^[\p{L}\p{N}\p{S}\p{M}_+\x{200D}-]+$`)
+	// Actions a moderator can take on a queue item
+	decisionPattern = regexp.MustCompile(`^(?:` + moderation.ActionDismiss + `|` + moderation.ActionHide + `|` +
+		moderation.ActionDelete + `|` + moderation.ActionBan + `)$`)
+)
+
+// Limits configures the validation of write payloads, lengths are counted in characters
+type Limits struct {
+	EmailLength    int
+	NicknameLength int
+	FullnameLength int
+	AboutLength    int
+	SlugLength     int
+	TitleLength    int
+	TagLength      int
+	FolderLength   int
+	MessageLength  int
+	ReasonLength   int
+	NoteLength     int
+	ReactionLength int
+	URLLength      int
+	SecretLength   int
+	PostsBatch     int
+	Recipients     int
+	Tags           int
+}
+
+func DefaultLimits() Limits {
+	return Limits{
+		EmailLength:    254,
+		NicknameLength: 64,
+		FullnameLength: 256,
+		AboutLength:    8192,
+		SlugLength:     128,
+		TitleLength:    256,
+		TagLength:      32,
+		FolderLength:   64,
+		MessageLength:  65536,
+		ReasonLength:   1024,
+		NoteLength:     4096,
+		ReactionLength: 32,
+		URLLength:      2048,
+		SecretLength:   256,
+		PostsBatch:     1000,
+		Recipients:     50,
+		Tags:           10,
+	}
+}
+
+func NewValidator(limits Limits) *Validator {
+	return &Validator{
+		limits: limits,
+	}
+}
+
+// Validator checks write payloads before they reach the repositories.
+// Every violation is collected, so clients get all rejected fields at once.
+type Validator struct {
+	limits Limits
+}
+
+func (v *Validator) User(data *user.User) error {
+	c := &checker{}
+	c.text("nickname", data.Nickname, v.limits.NicknameLength, nicknamePattern)
+	c.text("email", data.Email, v.limits.EmailLength, emailPattern)
+	c.text("fullname", data.Fullname, v.limits.FullnameLength, nil)
+	c.length("about", data.About, v.limits.AboutLength)
+	return c.err()
+}
+
+func (v *Validator) UserUpdate(data *user.Update) error {
+	c := &checker{}
+	if data.Email != nil {
+		c.text("email", *data.Email, v.limits.EmailLength, emailPattern)
+	}
+	if data.Fullname != nil {
+		c.text("fullname", *data.Fullname, v.limits.FullnameLength, nil)
+	}
+	if data.About != nil {
+		c.length("about", *data.About, v.limits.AboutLength)
+	}
+	return c.err()
+}
+
+func (v *Validator) Forum(data *forum.Create) error {
+	c := &checker{}
+	c.text("slug", data.Slug, v.limits.SlugLength, slugPattern)
+	c.text("title", data.Title, v.limits.TitleLength, nil)
+	c.text("user", data.UserNickname, v.limits.NicknameLength, nil)
+	if data.Parent != nil {
+		c.text("parent", *data.Parent, v.limits.SlugLength, slugPattern)
+	}
+	return c.err()
+}
+
+func (v *Validator) Thread(data *thread.Create) error {
+	c := &checker{}
+	if data.Slug != nil {
+		c.text("slug", *data.Slug, v.limits.SlugLength, slugPattern)
+	}
+	c.text("title", data.Title, v.limits.TitleLength, nil)
+	c.text("message", data.Message, v.limits.MessageLength, nil)
+	c.text("author", data.UserNickname, v.limits.NicknameLength, nil)
+	c.tags(data.Tags, v.limits.Tags, v.limits.TagLength)
+	return c.err()
+}
+
+func (v *Validator) ThreadUpdate(data *thread.Update) error {
+	c := &checker{}
+	if data.Title != nil {
+		c.text("title", *data.Title, v.limits.TitleLength, nil)
+	}
+	if data.Message != nil {
+		c.text("message", *data.Message, v.limits.MessageLength, nil)
+	}
+	if data.Tags != nil {
+		c.tags(*data.Tags, v.limits.Tags, v.limits.TagLength)
+	}
+	return c.err()
+}
+
+func (v *Validator) Posts(data *post.PostsCreate) error {
+	c := &checker{}
+	if len(*data) > v.limits.PostsBatch {
+		c.add(validation.Field{Name: "posts", Reason: validation.ReasonTooMany, Limit: v.limits.PostsBatch})
+		return c.err()
+	}
+
+	for i, newPost := range *data {
+		prefix := "posts[" + strconv.Itoa(i) + "]."
+		c.text(prefix+"message", newPost.Message, v.limits.MessageLength, nil)
+		c.text(prefix+"author", newPost.UserNickname, v.limits.NicknameLength, nil)
+	}
+	return c.err()
+}
+
+func (v *Validator) PostUpdate(data *post.Update) error {
+	c := &checker{}
+	if data.Message != nil {
+		c.text("message", *data.Message, v.limits.MessageLength, nil)
+	}
+	return c.err()
+}
+
+func (v *Validator) Reaction(data *vote.Reaction) error {
+	c := &checker{}
+	c.text("reaction", data.Reaction, v.limits.ReactionLength, reactionPattern)
+	return c.err()
+}
+
+func (v *Validator) Report(data *moderation.Report) error {
+	c := &checker{}
+	c.text("author", data.UserNickname, v.limits.NicknameLength, nil)
+	c.text("reason", data.Reason, v.limits.ReasonLength, nil)
+	return c.err()
+}
+
+func (v *Validator) Decision(data *moderation.Decision) error {
+	c := &checker{}
+	c.text("action", data.Action, v.limits.ReasonLength, decisionPattern)
+	c.length("note", data.Note, v.limits.ReasonLength)
+	return c.err()
+}
+
+func (v *Validator) Ban(data *ban.Create) error {
+	c := &checker{}
+	c.text("moderator", data.Moderator, v.limits.NicknameLength, nil)
+	if data.ForumSlug != nil {
+		c.text("forum", *data.ForumSlug, v.limits.SlugLength, slugPattern)
+	}
+	c.length("reason", data.Reason, v.limits.ReasonLength)
+	if data.Expires != nil && !data.Expires.After(time.Now()) {
+		c.add(validation.Field{Name: "expires", Reason: validation.ReasonPast})
+	}
+	return c.err()
+}
+
+func (v *Validator) Send(data *conversation.Send) error {
+	c := &checker{}
+	if data.ConversationID == nil {
+		switch {
+		case len(data.Recipients) == 0:
+			c.add(validation.Field{Name: "recipients", Reason: validation.ReasonRequired})
+		case len(data.Recipients) > v.limits.Recipients:
+			c.add(validation.Field{Name: "recipients", Reason: validation.ReasonTooMany, Limit: v.limits.Recipients})
+		default:
+			for i, recipient := range data.Recipients {
+				c.text("recipients["+strconv.Itoa(i)+"]", recipient, v.limits.NicknameLength, nicknamePattern)
+			}
+		}
+		c.length("subject", data.Subject, v.limits.TitleLength)
+	}
+	c.text("message", data.Message, v.limits.MessageLength, nil)
+	return c.err()
+}
+
+// Bookmark requires exactly one target, the post or the thread
+func (v *Validator) Bookmark(data *bookmark.Create) error {
+	c := &checker{}
+	switch {
+	case data.PostID == nil && data.Thread == nil:
+		c.add(validation.Field{Name: "post", Reason: validation.ReasonRequired})
+	case data.PostID != nil && data.Thread != nil:
+		c.add(validation.Field{Name: "thread", Reason: validation.ReasonConflict})
+	case data.Thread != nil:
+		c.text("thread", *data.Thread, v.limits.SlugLength, nil)
+	}
+	c.length("folder", data.Folder, v.limits.FolderLength)
+	c.length("note", data.Note, v.limits.NoteLength)
+	return c.err()
+}
+
+// Webhook accepts absolute http and https endpoints outside loopback and private networks
+func (v *Validator) Webhook(data *webhook.Create) error {
+	c := &checker{}
+	if c.length("url", data.URL, v.limits.URLLength) {
+		c.endpoint("url", data.URL)
+	}
+	if data.Secret != nil {
+		c.text("secret", *data.Secret, v.limits.SecretLength, nil)
+	}
+	c.events(data.Events)
+	return c.err()
+}
+
+func (v *Validator) WebhookUpdate(data *webhook.Update) error {
+	c := &checker{}
+	if data.URL != nil && c.length("url", *data.URL, v.limits.URLLength) {
+		c.endpoint("url", *data.URL)
+	}
+	if data.Secret != nil {
+		c.text("secret", *data.Secret, v.limits.SecretLength, nil)
+	}
+	c.events(data.Events)
+	return c.err()
+}
+
+// checker collects the violations of a single payload
+type checker struct {
+	fields []validation.Field
+}
+
+func (c *checker) add(field validation.Field) {
+	c.fields = append(c.fields, field)
+}
+
+// text checks a required field, pattern may be nil
+func (c *checker) text(name string, value string, max int, pattern *regexp.Regexp) {
+	if value == "" {
+		c.add(validation.Field{Name: name, Reason: validation.ReasonRequired})
+		return
+	}
+
+	if !c.length(name, value, max) {
+		return
+	}
+
+	if pattern != nil && !pattern.MatchString(value) {
+		c.add(validation.Field{Name: name, Reason: validation.ReasonFormat})
+	}
+}
+
+func (c *checker) length(name string, value string, max int) bool {
+	if utf8.RuneCountInString(value) > max {
+		c.add(validation.Field{Name: name, Reason: validation.ReasonTooLong, Limit: max})
+		return false
+	}
+
+	return true
+}
+
+func (c *checker) tags(tags []string, max int, length int) {
+	if len(tags) > max {
+		c.add(validation.Field{Name: "tags", Reason: validation.ReasonTooMany, Limit: max})
+		return
+	}
+
+	for i, tag := range tags {
+		c.text("tags["+strconv.Itoa(i)+"]", tag, length, tagPattern)
+	}
+}
+
+func (c *checker) endpoint(name string, raw string) {
+	if raw == "" {
+		c.add(validation.Field{Name: name, Reason: validation.ReasonRequired})
+		return
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || !webhook.PublicHost(parsed.Hostname()) {
+		c.add(validation.Field{Name: name, Reason: validation.ReasonFormat})
+	}
+}
+
+func (c *checker) events(events []string) {
+	for i, e := range events {
+		if !WebhookEvents[e] {
+			c.add(validation.Field{Name: "events[" + strconv.Itoa(i) + "]", Reason: validation.ReasonFormat})
+		}
+	}
+}
+
+func (c *checker) err() error {
+	if len(c.fields) == 0 {
+		return nil
+	}
+
+	return &validation.Error{
+		Description: "Invalid payload",
+		Fields:      c.fields,
+	}
+}
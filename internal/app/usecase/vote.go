@@ -1,21 +1,96 @@
 package usecase
 
 import (
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/post"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/thread"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/vote"
 	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
 )
 
-func NewVoteInteractor(repo repository.Vote) *VoteInteractor {
+func NewVoteInteractor(repo repository.Vote, validator *Validator, bans *BanInteractor) *VoteInteractor {
 	return &VoteInteractor{
 		repository: repo,
+		validator:  validator,
+		bans:       bans,
 	}
 }
 
 type VoteInteractor struct {
 	repository repository.Vote
+	validator  *Validator
+	bans       *BanInteractor
 }
 
 func (i *VoteInteractor) CreateVote(data *vote.Vote, slugOrId string) (*thread.Thread, error) {
-	return i.repository.CreateVote(data, slugOrId)
+	if err := i.bans.CheckThread([]string{data.UserNickname}, slugOrId); err != nil {
+		return nil, err
+	}
+
+	return i.repository.CreateVote(data, slugOrId, event.NewThreadVoted)
+}
+
+func (i *VoteInteractor) DeleteVote(data *vote.Vote, slugOrId string) (*thread.Thread, error) {
+	if err := i.bans.CheckThread([]string{data.UserNickname}, slugOrId); err != nil {
+		return nil, err
+	}
+
+	return i.repository.DeleteVote(data, slugOrId, event.NewThreadVoted)
+}
+
+func (i *VoteInteractor) GetThreadVotes(slugOrId string, limit *int, since *string, orderDesc bool) (*vote.Votes, error) {
+	return i.repository.GetThreadVotes(slugOrId, limit, since, orderDesc)
+}
+
+func (i *VoteInteractor) GetUserVotes(nickname string, limit *int, since *string, orderDesc bool) (*vote.Votes, error) {
+	return i.repository.GetUserVotes(nickname, limit, since, orderDesc)
+}
+
+func (i *VoteInteractor) CreatePostVote(data *vote.Vote, id string) (*post.Post, error) {
+	if err := i.bans.CheckPost([]string{data.UserNickname}, id); err != nil {
+		return nil, err
+	}
+
+	return i.repository.CreatePostVote(data, id, event.NewPostVoted)
+}
+
+func (i *VoteInteractor) DeletePostVote(data *vote.Vote, id string) (*post.Post, error) {
+	if err := i.bans.CheckPost([]string{data.UserNickname}, id); err != nil {
+		return nil, err
+	}
+
+	return i.repository.DeletePostVote(data, id, event.NewPostVoted)
+}
+
+func (i *VoteInteractor) CreateReaction(data *vote.Reaction, id string) (*post.Post, error) {
+	if err := i.validator.Reaction(data); err != nil {
+		return nil, err
+	}
+
+	if err := i.bans.CheckPost([]string{data.UserNickname}, id); err != nil {
+		return nil, err
+	}
+
+	return i.repository.CreateReaction(data, id, event.NewPostReacted)
+}
+
+func (i *VoteInteractor) DeleteReaction(data *vote.Reaction, id string) (*post.Post, error) {
+	if err := i.validator.Reaction(data); err != nil {
+		return nil, err
+	}
+
+	if err := i.bans.CheckPost([]string{data.UserNickname}, id); err != nil {
+		return nil, err
+	}
+
+	return i.repository.DeleteReaction(data, id, event.NewPostReacted)
+}
+
+func (i *VoteInteractor) CountThreadVotes(slugOrId string) (*page.Total, error) {
+	return i.repository.CountThreadVotes(slugOrId)
+}
+
+func (i *VoteInteractor) CountUserVotes(nickname string) (*page.Total, error) {
+	return i.repository.CountUserVotes(nickname)
 }
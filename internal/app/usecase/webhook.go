@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/webhook"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/usecase/repository"
+)
+
+const (
+	webhookBatch        = 50
+	webhookMaxAttempts  = 8
+	webhookBaseBackoff  = 5 * time.Second
+	webhookMaxBackoff   = time.Hour
+	webhookDisableAfter = 20
+	webhookSecretSize   = 32
+)
+
+// WebhookEvents are the forum events webhooks can subscribe to
+var WebhookEvents = map[string]bool{
+	event.TypeThreadCreated: true,
+	event.TypeThreadVoted:   true,
+	event.TypeThreadUpdated: true,
+	event.TypePostCreated:   true,
+	event.TypePostUpdated:   true,
+	event.TypePostVoted:     true,
+	event.TypePostReacted:   true,
+}
+
+func NewWebhookInteractor(repo repository.Webhook, sender repository.WebhookSender, moderation repository.Moderation, validator *Validator, identities *Identities) *WebhookInteractor {
+	return &WebhookInteractor{
+		repository: repo,
+		sender:     sender,
+		moderation: moderation,
+		validator:  validator,
+		identities: identities,
+	}
+}
+
+// WebhookInteractor manages the webhooks of a forum and sends their deliveries.
+// Webhooks and their deliveries are only available to the forum owner and moderators.
+type WebhookInteractor struct {
+	repository repository.Webhook
+	sender     repository.WebhookSender
+	moderation repository.Moderation
+	validator  *Validator
+	identities *Identities
+}
+
+// CreateWebhook generates a secret unless the caller provides one, it is only returned here
+func (i *WebhookInteractor) CreateWebhook(slug string, data *webhook.Create, token string) (*webhook.Webhook, error) {
+	if err := i.validator.Webhook(data); err != nil {
+		return nil, err
+	}
+
+	if err := i.authorize(slug, token); err != nil {
+		return nil, err
+	}
+
+	if data.Secret == nil {
+		raw := make([]byte, webhookSecretSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		secret := hex.EncodeToString(raw)
+		data.Secret = &secret
+	}
+
+	return i.repository.CreateWebhook(slug, data)
+}
+
+func (i *WebhookInteractor) GetWebhooks(slug, token string) (*webhook.Webhooks, error) {
+	if err := i.authorize(slug, token); err != nil {
+		return nil, err
+	}
+
+	return i.repository.GetWebhooks(slug)
+}
+
+func (i *WebhookInteractor) GetWebhook(slug string, id uint64, token string) (*webhook.Webhook, error) {
+	if err := i.authorize(slug, token); err != nil {
+		return nil, err
+	}
+
+	return i.repository.GetWebhook(slug, id)
+}
+
+func (i *WebhookInteractor) UpdateWebhook(slug string, id uint64, data *webhook.Update, token string) (*webhook.Webhook, error) {
+	if err := i.validator.WebhookUpdate(data); err != nil {
+		return nil, err
+	}
+
+	if err := i.authorize(slug, token); err != nil {
+		return nil, err
+	}
+
+	return i.repository.UpdateWebhook(slug, id, data)
+}
+
+func (i *WebhookInteractor) DeleteWebhook(slug string, id uint64, token string) error {
+	if err := i.authorize(slug, token); err != nil {
+		return err
+	}
+
+	return i.repository.DeleteWebhook(slug, id)
+}
+
+func (i *WebhookInteractor) GetDeliveries(slug string, id uint64, token string, limit *int, since *string, orderDesc bool) (*webhook.Deliveries, error) {
+	if err := i.authorize(slug, token); err != nil {
+		return nil, err
+	}
+
+	return i.repository.GetDeliveries(slug, id, limit, since, orderDesc)
+}
+
+func (i *WebhookInteractor) CountDeliveries(id uint64) (*page.Total, error) {
+	return i.repository.CountDeliveries(id)
+}
+
+// authorize lets the forum owner and moderators, identified by the token, manage its webhooks
+func (i *WebhookInteractor) authorize(slug, token string) error {
+	nickname, err := i.identities.Verify(token)
+	if err != nil {
+		return err
+	}
+
+	role, err := i.moderation.GetRole(slug, nickname)
+	if err != nil {
+		return err
+	}
+
+	if role == "" {
+		return errForbidden
+	}
+
+	return nil
+}
+
+// Handle queues a delivery of forum events for every matching webhook
+func (i *WebhookInteractor) Handle(e *event.Event) error {
+	if e.ForumSlug == "" || !WebhookEvents[e.Type] {
+		return nil
+	}
+
+	return i.repository.EnqueueDeliveries(e)
+}
+
+func (i *WebhookInteractor) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for {
+			sent, err := i.Deliver()
+			if err != nil {
+				log.Println("[Failed] delivering webhooks. Error:", err)
+				break
+			}
+			if sent < webhookBatch {
+				break
+			}
+		}
+	}
+}
+
+// Deliver sends a batch of due deliveries concurrently and records the outcome of each
+func (i *WebhookInteractor) Deliver() (int, error) {
+	jobs, err := i.repository.GetDueJobs(webhookBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for idx := range jobs {
+		wg.Add(1)
+		go func(job *webhook.Job) {
+			defer wg.Done()
+			if err := i.attempt(job); err != nil {
+				log.Println("[Failed] recording webhook delivery. Error:", err)
+			}
+		}(&jobs[idx])
+	}
+	wg.Wait()
+
+	return len(jobs), nil
+}
+
+func (i *WebhookInteractor) attempt(job *webhook.Job) error {
+	timestamp := time.Now().Unix()
+	headers := map[string]string{
+		"X-Webhook-Event":     job.EventType,
+		"X-Webhook-Delivery":  strconv.FormatUint(job.DeliveryID, 10),
+		"X-Webhook-Timestamp": strconv.FormatInt(timestamp, 10),
+		"X-Webhook-Signature": "sha256=" + Sign(job.Secret, timestamp, job.Payload),
+	}
+
+	status, err := i.sender.Send(job.URL, headers, job.Payload)
+	if err == nil && status >= 200 && status < 300 {
+		return i.repository.CompleteDelivery(job, status)
+	}
+
+	var received *int
+	reason := "unexpected status " + strconv.Itoa(status)
+	if err != nil {
+		reason = err.Error()
+	} else {
+		received = &status
+	}
+
+	var retryAt *time.Time
+	if attempts := job.Attempts + 1; attempts < webhookMaxAttempts {
+		next := time.Now().Add(webhookBackoff(attempts))
+		retryAt = &next
+	}
+
+	return i.repository.FailDelivery(job, received, reason, retryAt, webhookDisableAfter)
+}
+
+// webhookBackoff is the wait after a number of failed attempts, it doubles each time up to
+// webhookMaxBackoff and the delivery is given up after webhookMaxAttempts
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff << uint(attempts-1)
+	if backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
+
+// Sign returns the hex HMAC-SHA256 of the timestamp and body of a delivery joined by a dot.
+// Receivers recompute it with the shared secret and reject stale timestamps to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
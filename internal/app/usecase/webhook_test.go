@@ -0,0 +1,427 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/event"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/page"
+	"github.com/ZorinArsenij/tech-db-forum/internal/app/domain/webhook"
+	sender "github.com/ZorinArsenij/tech-db-forum/internal/app/infrastructure/webhook"
+	"github.com/valyala/fasthttp"
+)
+
+const testSecret = "secret"
+
+// memoryWebhooks keeps a single webhook and its deliveries the way the postgres repository does
+type memoryWebhooks struct {
+	mu         sync.Mutex
+	url        string
+	active     bool
+	failures   int
+	deliveries []*webhook.Job
+	states     map[uint64]string
+	retries    map[uint64]*time.Time
+	due        map[uint64]time.Time
+}
+
+func newMemoryWebhooks(url string) *memoryWebhooks {
+	return &memoryWebhooks{
+		url:     url,
+		active:  true,
+		states:  make(map[uint64]string),
+		retries: make(map[uint64]*time.Time),
+		due:     make(map[uint64]time.Time),
+	}
+}
+
+func (m *memoryWebhooks) CreateWebhook(slug string, data *webhook.Create) (*webhook.Webhook, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhooks) GetWebhooks(slug string) (*webhook.Webhooks, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhooks) GetWebhook(slug string, id uint64) (*webhook.Webhook, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhooks) UpdateWebhook(slug string, id uint64, data *webhook.Update) (*webhook.Webhook, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhooks) DeleteWebhook(slug string, id uint64) error {
+	return nil
+}
+
+func (m *memoryWebhooks) GetDeliveries(slug string, id uint64, limit *int, since *string, orderDesc bool) (*webhook.Deliveries, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhooks) CountDeliveries(id uint64) (*page.Total, error) {
+	return nil, nil
+}
+
+func (m *memoryWebhooks) EnqueueDeliveries(e *event.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.active {
+		return nil
+	}
+	for _, queued := range m.deliveries {
+		if queued.EventID == e.ID {
+			return nil
+		}
+	}
+
+	payload, err := e.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	id := uint64(len(m.deliveries) + 1)
+	m.deliveries = append(m.deliveries, &webhook.Job{
+		DeliveryID: id,
+		WebhookID:  1,
+		EventID:    e.ID,
+		EventType:  e.Type,
+		URL:        m.url,
+		Secret:     testSecret,
+		Payload:    payload,
+	})
+	m.states[id] = webhook.StatePending
+	return nil
+}
+
+func (m *memoryWebhooks) GetDueJobs(limit int) ([]webhook.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]webhook.Job, 0)
+	if !m.active {
+		return jobs, nil
+	}
+	for _, queued := range m.deliveries {
+		if len(jobs) < limit && m.states[queued.DeliveryID] == webhook.StatePending && !m.due[queued.DeliveryID].After(time.Now()) {
+			jobs = append(jobs, *queued)
+		}
+	}
+	return jobs, nil
+}
+
+func (m *memoryWebhooks) CompleteDelivery(job *webhook.Job, status int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deliveries[job.DeliveryID-1].Attempts++
+	m.states[job.DeliveryID] = webhook.StateDelivered
+	m.failures = 0
+	return nil
+}
+
+func (m *memoryWebhooks) FailDelivery(job *webhook.Job, status *int, reason string, retryAt *time.Time, disableAfter int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deliveries[job.DeliveryID-1].Attempts++
+	m.retries[job.DeliveryID] = retryAt
+	if retryAt == nil {
+		m.states[job.DeliveryID] = webhook.StateFailed
+	} else {
+		m.due[job.DeliveryID] = *retryAt
+	}
+
+	m.failures++
+	m.active = m.active && m.failures < disableAfter
+	return nil
+}
+
+// expire makes every pending delivery due without waiting for its backoff
+func (m *memoryWebhooks) expire() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id := range m.due {
+		m.due[id] = time.Time{}
+	}
+}
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is an endpoint answering with a settable status and recording every request
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []received
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests = append(r.requests, received{header: req.Header, body: body})
+		w.WriteHeader(r.status)
+	}))
+	return r
+}
+
+func (r *receiver) received() []received {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]received(nil), r.requests...)
+}
+
+func newTestWebhooks(t *testing.T, status int) (*WebhookInteractor, *memoryWebhooks, *receiver) {
+	endpoint := newReceiver(t, status)
+	repo := newMemoryWebhooks(endpoint.URL)
+	interactor := NewWebhookInteractor(repo, sender.NewSender(&fasthttp.Client{}, time.Second), nil, nil, nil)
+	return interactor, repo, endpoint
+}
+
+func postCreated(id uint64) *event.Event {
+	return &event.Event{
+		ID:        id,
+		Type:      event.TypePostCreated,
+		ThreadID:  1,
+		ForumSlug: "forum",
+		Data:      []byte(`{"id":` + strconv.FormatUint(id, 10) + `}`),
+	}
+}
+
+func deliver(t *testing.T, interactor *WebhookInteractor) int {
+	sent, err := interactor.Deliver()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sent
+}
+
+func TestWebhookSignature(t *testing.T) {
+	interactor, _, endpoint := newTestWebhooks(t, http.StatusOK)
+	defer endpoint.Close()
+
+	if err := interactor.Handle(postCreated(1)); err != nil {
+		t.Fatal(err)
+	}
+	deliver(t, interactor)
+
+	requests := endpoint.received()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	request := requests[0]
+
+	timestamp, err := strconv.ParseInt(request.header.Get("X-Webhook-Timestamp"), 10, 64)
+	if err != nil {
+		t.Fatalf("bad timestamp header: %v", err)
+	}
+	if age := time.Now().Unix() - timestamp; age < 0 || age > 5 {
+		t.Errorf("timestamp is %d seconds old", age)
+	}
+
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(request.body)
+	if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); request.header.Get("X-Webhook-Signature") != expected {
+		t.Errorf("signature %q, expected %q", request.header.Get("X-Webhook-Signature"), expected)
+	}
+
+	// A signature over the body alone, without the timestamp, must not verify
+	bare := hmac.New(sha256.New, []byte(testSecret))
+	bare.Write(request.body)
+	if request.header.Get("X-Webhook-Signature") == "sha256="+hex.EncodeToString(bare.Sum(nil)) {
+		t.Error("signature doesn't cover the timestamp")
+	}
+
+	if request.header.Get("X-Webhook-Event") != event.TypePostCreated {
+		t.Errorf("event header %q", request.header.Get("X-Webhook-Event"))
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	interactor, repo, endpoint := newTestWebhooks(t, http.StatusInternalServerError)
+	defer endpoint.Close()
+
+	if err := interactor.Handle(postCreated(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		before := time.Now()
+		if sent := deliver(t, interactor); sent != 1 {
+			t.Fatalf("attempt %d: expected 1 due delivery, got %d", attempt, sent)
+		}
+
+		retryAt := repo.retries[1]
+		if attempt == webhookMaxAttempts {
+			if retryAt != nil || repo.states[1] != webhook.StateFailed {
+				t.Fatalf("delivery not given up after %d attempts", attempt)
+			}
+			break
+		}
+
+		expected := webhookBaseBackoff << uint(attempt-1)
+		if expected > webhookMaxBackoff {
+			expected = webhookMaxBackoff
+		}
+		if retryAt == nil || retryAt.Before(before.Add(expected)) || retryAt.After(time.Now().Add(expected)) {
+			t.Fatalf("attempt %d: retry at %v, expected %v later", attempt, retryAt, expected)
+		}
+
+		// Not due until the backoff passes
+		if sent := deliver(t, interactor); sent != 0 {
+			t.Fatalf("attempt %d: delivery retried before its backoff", attempt)
+		}
+		repo.expire()
+	}
+
+	if requests := endpoint.received(); len(requests) != webhookMaxAttempts {
+		t.Errorf("expected %d requests, got %d", webhookMaxAttempts, len(requests))
+	}
+
+	schedule := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second}
+	for i, expected := range schedule {
+		if backoff := webhookBackoff(i + 1); backoff != expected {
+			t.Errorf("backoff after %d attempts is %v, expected %v", i+1, backoff, expected)
+		}
+	}
+	if backoff := webhookBackoff(20); backoff != webhookMaxBackoff {
+		t.Errorf("backoff isn't capped, got %v", backoff)
+	}
+}
+
+func TestWebhookAutoDisable(t *testing.T) {
+	interactor, repo, endpoint := newTestWebhooks(t, http.StatusServiceUnavailable)
+	defer endpoint.Close()
+
+	for id := uint64(1); id <= webhookDisableAfter; id++ {
+		if err := interactor.Handle(postCreated(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deliver(t, interactor)
+	if repo.active {
+		t.Fatalf("webhook still active after %d failures", repo.failures)
+	}
+
+	// Nothing more is queued or sent once disabled
+	if err := interactor.Handle(postCreated(webhookDisableAfter + 1)); err != nil {
+		t.Fatal(err)
+	}
+	repo.expire()
+	if sent := deliver(t, interactor); sent != 0 {
+		t.Errorf("disabled webhook got %d deliveries", sent)
+	}
+	if requests := endpoint.received(); len(requests) != webhookDisableAfter {
+		t.Errorf("expected %d requests, got %d", webhookDisableAfter, len(requests))
+	}
+}
+
+func TestWebhookSuccessResetsFailures(t *testing.T) {
+	interactor, repo, endpoint := newTestWebhooks(t, http.StatusInternalServerError)
+	defer endpoint.Close()
+
+	for id := uint64(1); id < webhookDisableAfter; id++ {
+		if err := interactor.Handle(postCreated(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deliver(t, interactor)
+
+	endpoint.mu.Lock()
+	endpoint.status = http.StatusOK
+	endpoint.mu.Unlock()
+
+	repo.expire()
+	deliver(t, interactor)
+	if !repo.active || repo.failures != 0 {
+		t.Errorf("failures not reset by a success, active %v with %d failures", repo.active, repo.failures)
+	}
+}
+
+func TestWebhookRedelivery(t *testing.T) {
+	interactor, repo, endpoint := newTestWebhooks(t, http.StatusInternalServerError)
+	defer endpoint.Close()
+
+	// The outbox delivers at least once, the same event may reach the subscriber again
+	for i := 0; i < 3; i++ {
+		if err := interactor.Handle(postCreated(1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deliver(t, interactor)
+
+	endpoint.mu.Lock()
+	endpoint.status = http.StatusOK
+	endpoint.mu.Unlock()
+
+	repo.expire()
+	deliver(t, interactor)
+	if err := interactor.Handle(postCreated(1)); err != nil {
+		t.Fatal(err)
+	}
+	repo.expire()
+	deliver(t, interactor)
+
+	requests := endpoint.received()
+	if len(requests) != 2 {
+		t.Fatalf("expected a failed and a retried request, got %d", len(requests))
+	}
+
+	// Retries carry the same delivery id so receivers can drop what they already processed
+	first, retried := requests[0].header.Get("X-Webhook-Delivery"), requests[1].header.Get("X-Webhook-Delivery")
+	if first == "" || first != retried {
+		t.Errorf("delivery ids %q and %q differ", first, retried)
+	}
+	if string(requests[0].body) != string(requests[1].body) {
+		t.Error("retried body differs")
+	}
+}
+
+func TestPublicHost(t *testing.T) {
+	hosts := map[string]bool{
+		"example.com":     true,
+		"93.184.216.34":   true,
+		"localhost":       false,
+		"api.localhost":   false,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"0.0.0.0":         false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"fd00::1":         false,
+	}
+
+	for host, public := range hosts {
+		if webhook.PublicHost(host) != public {
+			t.Errorf("PublicHost(%q) = %v", host, !public)
+		}
+	}
+
+	if _, err := sender.PublicDial(time.Second)("127.0.0.1:80"); err != sender.ErrPrivateAddress {
+		t.Errorf("dialing loopback returned %v", err)
+	}
+}